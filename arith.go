@@ -0,0 +1,233 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+	"math/bits"
+)
+
+// The functions in this file treat a UUID as an unsigned 128 bit big-endian
+// integer, so that the numeric order matches the order of Compare.  They do
+// not set or preserve the version and variant bits.
+
+var (
+	// ErrOutOfRange is returned for a value that does not fit in a UUID.
+	ErrOutOfRange = errors.New("value out of UUID range")
+
+	// ErrInvalidRange is returned for a UUIDRange whose Start is greater
+	// than its End.
+	ErrInvalidRange = errors.New("invalid UUID range")
+)
+
+// maxUint128 is 2**128 - 1, the numeric value of Max.
+var maxUint128 = new(big.Int).SetBytes(Max[:])
+
+// FromUint64Pair returns the UUID whose numeric value is hi<<64 | lo.
+func FromUint64Pair(hi, lo uint64) UUID {
+	var uuid UUID
+	binary.BigEndian.PutUint64(uuid[0:], hi)
+	binary.BigEndian.PutUint64(uuid[8:], lo)
+	return uuid
+}
+
+// Uint64Pair returns the most and least significant 64 bits of uuid.
+func (uuid UUID) Uint64Pair() (hi, lo uint64) {
+	return binary.BigEndian.Uint64(uuid[0:]), binary.BigEndian.Uint64(uuid[8:])
+}
+
+// FromBigInt returns the UUID whose numeric value is x.  ErrOutOfRange is
+// returned if x is negative or does not fit in 128 bits.
+func FromBigInt(x *big.Int) (UUID, error) {
+	var uuid UUID
+	if x.Sign() < 0 || x.Cmp(maxUint128) > 0 {
+		return uuid, ErrOutOfRange
+	}
+	x.FillBytes(uuid[:])
+	return uuid, nil
+}
+
+// BigInt returns the numeric value of uuid as a newly allocated big.Int.
+func (uuid UUID) BigInt() *big.Int {
+	return new(big.Int).SetBytes(uuid[:])
+}
+
+// Add returns uuid + n.  If the sum does not fit in 128 bits the result wraps
+// around and ok is false.
+func (uuid UUID) Add(n uint64) (sum UUID, ok bool) {
+	hi, lo := uuid.Uint64Pair()
+	lo, carry := bits.Add64(lo, n, 0)
+	hi, carry = bits.Add64(hi, 0, carry)
+	return FromUint64Pair(hi, lo), carry == 0
+}
+
+// Sub returns uuid - n.  If n is greater than uuid the result wraps around and
+// ok is false.
+func (uuid UUID) Sub(n uint64) (diff UUID, ok bool) {
+	hi, lo := uuid.Uint64Pair()
+	lo, borrow := bits.Sub64(lo, n, 0)
+	hi, borrow = bits.Sub64(hi, 0, borrow)
+	return FromUint64Pair(hi, lo), borrow == 0
+}
+
+// Next returns the UUID numerically following uuid.  ok is false if uuid is
+// Max, in which case Nil is returned.
+func (uuid UUID) Next() (next UUID, ok bool) {
+	return uuid.Add(1)
+}
+
+// Prev returns the UUID numerically preceding uuid.  ok is false if uuid is
+// Nil, in which case Max is returned.
+func (uuid UUID) Prev() (prev UUID, ok bool) {
+	return uuid.Sub(1)
+}
+
+// sub128 returns a - b, ignoring any borrow.
+func sub128(a, b UUID) (hi, lo uint64) {
+	ahi, alo := a.Uint64Pair()
+	bhi, blo := b.Uint64Pair()
+	lo, borrow := bits.Sub64(alo, blo, 0)
+	hi, _ = bits.Sub64(ahi, bhi, borrow)
+	return hi, lo
+}
+
+// add128 returns uuid + hi<<64 | lo, ignoring any carry.
+func add128(uuid UUID, hi, lo uint64) UUID {
+	uhi, ulo := uuid.Uint64Pair()
+	lo, carry := bits.Add64(ulo, lo, 0)
+	hi, _ = bits.Add64(uhi, hi, carry)
+	return FromUint64Pair(hi, lo)
+}
+
+// A UUIDRange is the set of UUIDs numerically between Start and End,
+// inclusive.  The zero value is the range holding only Nil, and
+// UUIDRange{Nil, Max} is the range holding every UUID.
+type UUIDRange struct {
+	Start UUID
+	End   UUID
+}
+
+// FullRange is the range holding every UUID.
+var FullRange = UUIDRange{Start: Nil, End: Max}
+
+// Valid reports whether Start is not greater than End.
+func (r UUIDRange) Valid() bool {
+	return Compare(r.Start, r.End) <= 0
+}
+
+// Contains reports whether uuid is in r.
+func (r UUIDRange) Contains(uuid UUID) bool {
+	return Compare(r.Start, uuid) <= 0 && Compare(uuid, r.End) <= 0
+}
+
+// Size returns the number of UUIDs in r, or 0 if r is not valid.
+func (r UUIDRange) Size() *big.Int {
+	if !r.Valid() {
+		return new(big.Int)
+	}
+	n := r.End.BigInt()
+	n.Sub(n, r.Start.BigInt())
+	return n.Add(n, big.NewInt(1))
+}
+
+// Midpoint returns the UUID halfway between Start and End, rounded down.
+// Midpoint splits r in two, the lower half ending at the midpoint.
+// ErrInvalidRange is returned if r is not valid.
+func (r UUIDRange) Midpoint() (UUID, error) {
+	if !r.Valid() {
+		return Nil, ErrInvalidRange
+	}
+	hi, lo := sub128(r.End, r.Start)
+	lo = lo>>1 | hi<<63
+	hi >>= 1
+	return add128(r.Start, hi, lo), nil
+}
+
+// Split divides r into n contiguous ranges whose sizes differ by at most one,
+// the larger ranges first.  Fewer than n ranges are returned if r holds fewer
+// than n UUIDs.  Split returns nil if n is less than 1 or r is not valid.
+func (r UUIDRange) Split(n int) []UUIDRange {
+	if n < 1 || !r.Valid() {
+		return nil
+	}
+	if n == 1 {
+		return []UUIDRange{r}
+	}
+	// The size of r is d+1, which overflows 128 bits for FullRange, so
+	// divide d by n and then correct for the final 1.
+	dhi, dlo := sub128(r.End, r.Start)
+	qhi, rem := bits.Div64(0, dhi, uint64(n))
+	qlo, rem := bits.Div64(rem, dlo, uint64(n))
+	if rem++; rem == uint64(n) {
+		var c uint64
+		qlo, c = bits.Add64(qlo, 1, 0)
+		qhi += c
+		rem = 0
+	}
+	if qhi == 0 && qlo == 0 {
+		// Fewer UUIDs than ranges: one range per UUID.
+		n = int(rem)
+		rem = 0
+		qlo = 1
+	}
+
+	ranges := make([]UUIDRange, n)
+	start := r.Start
+	for i := range ranges {
+		shi, slo := qhi, qlo
+		if uint64(i) < rem {
+			var c uint64
+			slo, c = bits.Add64(slo, 1, 0)
+			shi += c
+		}
+		// end = start + size - 1
+		end := add128(start, shi, slo)
+		end, _ = end.Prev()
+		ranges[i] = UUIDRange{Start: start, End: end}
+		start, _ = end.Next()
+	}
+	return ranges
+}
+
+// NewRandomInRange returns a UUID chosen uniformly at random from r using the
+// random number generator set by SetRand.  The version and variant bits are
+// not set, the result is just a number in r.  ErrInvalidRange is returned if
+// r is not valid.
+func NewRandomInRange(r UUIDRange) (UUID, error) {
+	return NewRandomInRangeFromReader(r, randSource())
+}
+
+// NewRandomInRangeFromReader is like NewRandomInRange but reads its random
+// bits from rand.
+func NewRandomInRangeFromReader(r UUIDRange, rand io.Reader) (UUID, error) {
+	if !r.Valid() {
+		return Nil, ErrInvalidRange
+	}
+	dhi, dlo := sub128(r.End, r.Start)
+	// Draw numbers no wider than d until one is not greater than d.  At
+	// least half of all draws succeed.
+	mhi, mlo := ^uint64(0), ^uint64(0)
+	if dhi != 0 {
+		mhi >>= bits.LeadingZeros64(dhi)
+	} else {
+		mhi = 0
+		mlo >>= bits.LeadingZeros64(dlo)
+	}
+	var b UUID
+	for {
+		if _, err := io.ReadFull(rand, b[:]); err != nil {
+			return Nil, err
+		}
+		xhi, xlo := b.Uint64Pair()
+		xhi &= mhi
+		xlo &= mlo
+		if xhi < dhi || (xhi == dhi && xlo <= dlo) {
+			return add128(r.Start, xhi, xlo), nil
+		}
+	}
+}
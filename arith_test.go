@@ -0,0 +1,159 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBigInt(t *testing.T) {
+	for _, uuid := range []UUID{Nil, Max, MustParse("f47ac10b-58cc-4372-8567-0e02b2c3d479")} {
+		got, err := FromBigInt(uuid.BigInt())
+		if err != nil {
+			t.Fatalf("FromBigInt(%s): %v", uuid, err)
+		}
+		if got != uuid {
+			t.Errorf("got %s, want %s", got, uuid)
+		}
+	}
+	tooBig := new(big.Int).Lsh(big.NewInt(1), 128)
+	if _, err := FromBigInt(tooBig); err != ErrOutOfRange {
+		t.Errorf("FromBigInt(2**128) got error %v, want %v", err, ErrOutOfRange)
+	}
+	if _, err := FromBigInt(big.NewInt(-1)); err != ErrOutOfRange {
+		t.Errorf("FromBigInt(-1) got error %v, want %v", err, ErrOutOfRange)
+	}
+	want := new(big.Int).Lsh(big.NewInt(1), 64)
+	want.Add(want, big.NewInt(2))
+	if got := FromUint64Pair(1, 2).BigInt(); got.Cmp(want) != 0 {
+		t.Errorf("FromUint64Pair(1, 2) is %v, want %v", got, want)
+	}
+}
+
+func TestAddSub(t *testing.T) {
+	carry := FromUint64Pair(0, ^uint64(0))
+	if got, ok := carry.Add(1); !ok || got != FromUint64Pair(1, 0) {
+		t.Errorf("Add carry got %s %v", got, ok)
+	}
+	if got, ok := FromUint64Pair(1, 0).Sub(1); !ok || got != carry {
+		t.Errorf("Sub borrow got %s %v", got, ok)
+	}
+	if got, ok := Max.Next(); ok || got != Nil {
+		t.Errorf("Max.Next() got %s %v, want %s false", got, ok, Nil)
+	}
+	if got, ok := Nil.Prev(); ok || got != Max {
+		t.Errorf("Nil.Prev() got %s %v, want %s false", got, ok, Max)
+	}
+}
+
+func TestUUIDRange(t *testing.T) {
+	r := UUIDRange{FromUint64Pair(0, 10), FromUint64Pair(0, 20)}
+	for _, tt := range []struct {
+		uuid UUID
+		want bool
+	}{
+		{FromUint64Pair(0, 9), false},
+		{FromUint64Pair(0, 10), true},
+		{FromUint64Pair(0, 20), true},
+		{FromUint64Pair(1, 15), false},
+	} {
+		if got := r.Contains(tt.uuid); got != tt.want {
+			t.Errorf("Contains(%s) got %v, want %v", tt.uuid, got, tt.want)
+		}
+	}
+	if got, err := r.Midpoint(); got != FromUint64Pair(0, 15) || err != nil {
+		t.Errorf("Midpoint got %s, %v, want %s", got, err, FromUint64Pair(0, 15))
+	}
+	if got, err := FullRange.Midpoint(); got != FromUint64Pair(1<<63-1, ^uint64(0)) || err != nil {
+		t.Errorf("FullRange.Midpoint got %s, %v", got, err)
+	}
+	if _, err := (UUIDRange{Start: r.End, End: r.Start}).Midpoint(); err != ErrInvalidRange {
+		t.Errorf("Midpoint of an invalid range got error %v, want %v", err, ErrInvalidRange)
+	}
+	if got := r.Size(); got.Int64() != 11 {
+		t.Errorf("Size got %v, want 11", got)
+	}
+}
+
+func TestUUIDRangeSplit(t *testing.T) {
+	for _, tt := range []struct {
+		r     UUIDRange
+		n     int
+		wantN int
+	}{
+		{FullRange, 1, 1},
+		{FullRange, 2, 2},
+		{FullRange, 3, 3},
+		{FullRange, 16, 16},
+		{UUIDRange{FromUint64Pair(0, 10), FromUint64Pair(0, 20)}, 4, 4},
+		{UUIDRange{FromUint64Pair(0, 10), FromUint64Pair(0, 12)}, 5, 3},
+		{UUIDRange{FromUint64Pair(0, 10), FromUint64Pair(0, 12)}, 0, 0},
+		{UUIDRange{FromUint64Pair(0, 12), FromUint64Pair(0, 10)}, 2, 0},
+	} {
+		parts := tt.r.Split(tt.n)
+		if len(parts) != tt.wantN {
+			t.Errorf("%v.Split(%d) got %d ranges, want %d", tt.r, tt.n, len(parts), tt.wantN)
+			continue
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		total := new(big.Int)
+		min, max := parts[0].Size(), parts[0].Size()
+		for i, p := range parts {
+			if i == 0 && p.Start != tt.r.Start {
+				t.Errorf("%v.Split(%d) starts at %s", tt.r, tt.n, p.Start)
+			}
+			if i > 0 {
+				if next, _ := parts[i-1].End.Next(); next != p.Start {
+					t.Errorf("%v.Split(%d): gap between %v and %v", tt.r, tt.n, parts[i-1], p)
+				}
+			}
+			s := p.Size()
+			total.Add(total, s)
+			if s.Cmp(min) < 0 {
+				min = s
+			}
+			if s.Cmp(max) > 0 {
+				max = s
+			}
+		}
+		if parts[len(parts)-1].End != tt.r.End {
+			t.Errorf("%v.Split(%d) ends at %s", tt.r, tt.n, parts[len(parts)-1].End)
+		}
+		if total.Cmp(tt.r.Size()) != 0 {
+			t.Errorf("%v.Split(%d) covers %v UUIDs, want %v", tt.r, tt.n, total, tt.r.Size())
+		}
+		if new(big.Int).Sub(max, min).Cmp(big.NewInt(1)) > 0 {
+			t.Errorf("%v.Split(%d) sizes range from %v to %v", tt.r, tt.n, min, max)
+		}
+	}
+}
+
+func TestNewRandomInRange(t *testing.T) {
+	r := UUIDRange{FromUint64Pair(5, 10), FromUint64Pair(5, 12)}
+	seen := make(map[UUID]bool)
+	for i := 0; i < 1000; i++ {
+		uuid, err := NewRandomInRange(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !r.Contains(uuid) {
+			t.Fatalf("%s is not in %v", uuid, r)
+		}
+		seen[uuid] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("got %d distinct UUIDs, want 3", len(seen))
+	}
+	single := UUIDRange{Max, Max}
+	if uuid, err := NewRandomInRange(single); err != nil || uuid != Max {
+		t.Errorf("got %s %v, want %s", uuid, err, Max)
+	}
+	if _, err := NewRandomInRange(UUIDRange{Max, Nil}); err != ErrInvalidRange {
+		t.Errorf("got error %v, want %v", err, ErrInvalidRange)
+	}
+}
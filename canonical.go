@@ -0,0 +1,420 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidName is returned, wrapped, by NewDNS, NewURL, NewOID and NewX500
+// for names that are not valid in their name space.
+var ErrInvalidName = errors.New("invalid name")
+
+// NewDNS returns the SHA1 (Version 5) UUID of the DNS name name in
+// NameSpaceDNS, after bringing name into a canonical form, so that all
+// spellings of a name give the same UUID: the name is lowercased, labels
+// with non-ASCII characters are converted to their IDNA "xn--" form with
+// Punycode (RFC 3492), and a trailing dot is removed.  Only lowercasing is
+// applied of the mappings of UTS #46.  For example "WWW.Example.COM." and
+// "www.example.com" give the same UUID, which is
+//
+//	NewSHA1(NameSpaceDNS, []byte("www.example.com"))
+func NewDNS(name string) (UUID, error) {
+	s, err := canonicalDNS(name)
+	if err != nil {
+		return Nil, err
+	}
+	return NewSHA1(NameSpaceDNS, []byte(s)), nil
+}
+
+// NewURL returns the SHA1 (Version 5) UUID of the URL rawURL in
+// NameSpaceURL, after normalizing it as in RFC 3986 section 6.2.2 and 6.2.3:
+// the scheme and host are lowercased, the host is brought into the form of
+// NewDNS, the default port of http, https, ws and wss is removed, an empty
+// path of a URL with a host becomes "/", percent-encodings are uppercased and
+// decoded where they encode unreserved characters, and the dot-segments "."
+// and ".." are removed from the path.  For example "HTTP://Example.com:80"
+// and "http://example.com/" give the same UUID.  rawURL must be absolute.
+func NewURL(rawURL string) (UUID, error) {
+	s, err := canonicalURL(rawURL)
+	if err != nil {
+		return Nil, err
+	}
+	return NewSHA1(NameSpaceURL, []byte(s)), nil
+}
+
+// NewOID returns the SHA1 (Version 5) UUID of the object identifier oid, in
+// dotted decimal form such as "1.3.6.1.4.1", in NameSpaceOID.  oid must have at
+// least two arcs, no leading zeros, a first arc of 0, 1 or 2, and a second arc
+// below 40 if the first is 0 or 1.
+func NewOID(oid string) (UUID, error) {
+	if err := checkOID(oid); err != nil {
+		return Nil, err
+	}
+	return NewSHA1(NameSpaceOID, []byte(oid)), nil
+}
+
+// NewX500 returns the SHA1 (Version 5) UUID of the X.500 distinguished name
+// dn, in the string form of RFC 4514 such as "CN=Steve Kille,O=Isode
+// Limited,C=GB", in NameSpaceX500.  The name hashed is the DER encoding of dn,
+// as in certificates, so different spellings of the same name, such as
+// "cn=Steve Kille, o=Isode Limited, c=GB", give the same UUID.  Attribute
+// values are encoded as PrintableString for C, IA5String for DC and
+// emailAddress, and UTF8String otherwise.  Attribute types may also be given
+// as dotted OIDs, and values as "#" followed by their BER encoding in hex.
+func NewX500(dn string) (UUID, error) {
+	der, err := x500DER(dn)
+	if err != nil {
+		return Nil, err
+	}
+	return NewSHA1(NameSpaceX500, der), nil
+}
+
+// canonicalDNS returns the canonical form of the DNS name name.
+func canonicalDNS(name string) (string, error) {
+	if !utf8.ValidString(name) {
+		return "", fmt.Errorf("%w: DNS name %q", ErrInvalidName, name)
+	}
+	s := strings.TrimSuffix(strings.ToLower(name), ".")
+	labels := strings.Split(s, ".")
+	for i, label := range labels {
+		for _, c := range label {
+			if c >= utf8.RuneSelf {
+				label = "xn--" + punycode(label)
+				break
+			}
+		}
+		if label == "" || len(label) > 63 {
+			return "", fmt.Errorf("%w: DNS name %q", ErrInvalidName, name)
+		}
+		labels[i] = label
+	}
+	s = strings.Join(labels, ".")
+	if len(s) > 253 {
+		return "", fmt.Errorf("%w: DNS name %q too long", ErrInvalidName, name)
+	}
+	return s, nil
+}
+
+// defaultPorts are the ports removed from URLs of their scheme.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"ws":    "80",
+	"wss":   "443",
+}
+
+// canonicalURL returns the normalized form of the absolute URL rawURL.
+func canonicalURL(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidName, err)
+	}
+	if u.Scheme == "" {
+		return "", fmt.Errorf("%w: URL %q is not absolute", ErrInvalidName, rawURL)
+	}
+	var b strings.Builder
+	b.WriteString(strings.ToLower(u.Scheme))
+	b.WriteByte(':')
+	if u.Opaque != "" {
+		b.WriteString(normalizePercent(u.Opaque))
+	} else {
+		path := removeDotSegments(normalizePercent(u.EscapedPath()))
+		if u.Host != "" || u.User != nil || !u.OmitHost && path != "" {
+			b.WriteString("//")
+			if u.User != nil {
+				b.WriteString(normalizePercent(u.User.String()))
+				b.WriteByte('@')
+			}
+			host, port := u.Hostname(), u.Port()
+			if strings.Contains(host, ":") {
+				b.WriteString("[" + strings.ToLower(host) + "]")
+			} else if host != "" {
+				if host, err = url.PathUnescape(host); err != nil {
+					return "", fmt.Errorf("%w: %v", ErrInvalidName, err)
+				}
+				if host, err = canonicalDNS(host); err != nil {
+					return "", err
+				}
+				b.WriteString(host)
+			}
+			if port != "" && port != defaultPorts[strings.ToLower(u.Scheme)] {
+				b.WriteString(":" + port)
+			}
+		}
+		if path == "" && u.Host != "" {
+			path = "/"
+		}
+		b.WriteString(path)
+	}
+	if u.ForceQuery || u.RawQuery != "" {
+		b.WriteString("?" + normalizePercent(u.RawQuery))
+	}
+	if u.Fragment != "" {
+		b.WriteString("#" + normalizePercent(u.EscapedFragment()))
+	}
+	return b.String(), nil
+}
+
+// normalizePercent uppercases the hex digits of the percent-encodings in s,
+// and decodes those of unreserved characters.
+func normalizePercent(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && i+2 < len(s) {
+			if c, ok := xtob(s[i+1], s[i+2]); ok {
+				if isUnreserved(c) {
+					b.WriteByte(c)
+				} else {
+					b.WriteString(strings.ToUpper(s[i : i+3]))
+				}
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// isUnreserved reports whether c is an unreserved character of RFC 3986.
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+// removeDotSegments implements RFC 3986 section 5.2.4.
+func removeDotSegments(path string) string {
+	var out []string
+	in := path
+	for in != "" {
+		switch {
+		case strings.HasPrefix(in, "../"):
+			in = in[3:]
+		case strings.HasPrefix(in, "./"):
+			in = in[2:]
+		case strings.HasPrefix(in, "/./"):
+			in = in[2:]
+		case in == "/.":
+			in = "/"
+		case strings.HasPrefix(in, "/../"):
+			in = in[3:]
+			if len(out) > 0 {
+				out = out[:len(out)-1]
+			}
+		case in == "/..":
+			in = "/"
+			if len(out) > 0 {
+				out = out[:len(out)-1]
+			}
+		case in == "." || in == "..":
+			in = ""
+		default:
+			// Move the first segment, with its leading "/", to out.
+			i := strings.IndexByte(in[1:], '/')
+			if i < 0 {
+				out = append(out, in)
+				in = ""
+			} else {
+				out = append(out, in[:i+1])
+				in = in[i+1:]
+			}
+		}
+	}
+	return strings.Join(out, "")
+}
+
+// checkOID returns an error if oid is not a valid dotted decimal object
+// identifier.
+func checkOID(oid string) error {
+	arcs := strings.Split(oid, ".")
+	if len(arcs) < 2 {
+		return fmt.Errorf("%w: OID %q", ErrInvalidName, oid)
+	}
+	for _, arc := range arcs {
+		if arc == "" || (arc[0] == '0' && len(arc) > 1) {
+			return fmt.Errorf("%w: OID %q", ErrInvalidName, oid)
+		}
+		for i := 0; i < len(arc); i++ {
+			if arc[i] < '0' || arc[i] > '9' {
+				return fmt.Errorf("%w: OID %q", ErrInvalidName, oid)
+			}
+		}
+	}
+	switch arcs[0] {
+	case "0", "1":
+		if len(arcs[1]) > 2 || len(arcs[1]) == 2 && arcs[1] >= "40" {
+			return fmt.Errorf("%w: OID %q", ErrInvalidName, oid)
+		}
+	case "2":
+	default:
+		return fmt.Errorf("%w: OID %q", ErrInvalidName, oid)
+	}
+	return nil
+}
+
+// x500Types are the attribute types of RFC 4514 section 3, and a few other
+// common ones, by their uppercased short names.
+var x500Types = map[string]asn1.ObjectIdentifier{
+	"CN":           {2, 5, 4, 3},
+	"SERIALNUMBER": {2, 5, 4, 5},
+	"C":            {2, 5, 4, 6},
+	"L":            {2, 5, 4, 7},
+	"ST":           {2, 5, 4, 8},
+	"STREET":       {2, 5, 4, 9},
+	"O":            {2, 5, 4, 10},
+	"OU":           {2, 5, 4, 11},
+	"POSTALCODE":   {2, 5, 4, 17},
+	"DC":           {0, 9, 2342, 19200300, 100, 1, 25},
+	"UID":          {0, 9, 2342, 19200300, 100, 1, 1},
+	"EMAILADDRESS": {1, 2, 840, 113549, 1, 9, 1},
+}
+
+// x500DER returns the DER encoding of the RFC 4514 distinguished name dn.
+func x500DER(dn string) ([]byte, error) {
+	rdns, err := splitDN(dn)
+	if err != nil {
+		return nil, err
+	}
+	// The string form lists the RDNs from the last to the first.
+	seq := make(pkix.RDNSequence, len(rdns))
+	for i, rdn := range rdns {
+		set := make(pkix.RelativeDistinguishedNameSET, len(rdn))
+		for j, atv := range rdn {
+			if set[j], err = x500Attribute(atv[0], atv[1]); err != nil {
+				return nil, fmt.Errorf("%w: DN %q: %v", ErrInvalidName, dn, err)
+			}
+		}
+		seq[len(rdns)-1-i] = set
+	}
+	return asn1.Marshal(seq)
+}
+
+// x500Attribute returns the attribute of the type and value strings.
+func x500Attribute(typ, value string) (pkix.AttributeTypeAndValue, error) {
+	var atv pkix.AttributeTypeAndValue
+	typ = strings.TrimSpace(typ)
+	if oid, ok := x500Types[strings.ToUpper(typ)]; ok {
+		atv.Type = oid
+	} else if checkOID(typ) == nil {
+		for _, arc := range strings.Split(typ, ".") {
+			var n int
+			if _, err := fmt.Sscan(arc, &n); err != nil {
+				return atv, fmt.Errorf("attribute type %q", typ)
+			}
+			atv.Type = append(atv.Type, n)
+		}
+	} else {
+		return atv, fmt.Errorf("unknown attribute type %q", typ)
+	}
+
+	if strings.HasPrefix(value, "#") {
+		der, err := hex.DecodeString(value[1:])
+		if err != nil {
+			return atv, fmt.Errorf("attribute value %q", value)
+		}
+		var raw asn1.RawValue
+		if rest, err := asn1.Unmarshal(der, &raw); err != nil || len(rest) != 0 {
+			return atv, fmt.Errorf("attribute value %q", value)
+		}
+		atv.Value = raw
+		return atv, nil
+	}
+	if !utf8.ValidString(value) {
+		return atv, fmt.Errorf("attribute value %q is not UTF-8", value)
+	}
+	tag := asn1.TagUTF8String
+	switch {
+	case atv.Type.Equal(x500Types["C"]):
+		tag = asn1.TagPrintableString
+	case atv.Type.Equal(x500Types["DC"]), atv.Type.Equal(x500Types["EMAILADDRESS"]):
+		tag = asn1.TagIA5String
+	}
+	atv.Value = asn1.RawValue{Tag: tag, Bytes: []byte(value)}
+	return atv, nil
+}
+
+// splitDN splits the RFC 4514 distinguished name dn into its RDNs, each a
+// list of attribute type and unescaped value pairs.  Spaces around the
+// separators and unescaped leading and trailing spaces of values are removed.
+func splitDN(dn string) ([][][2]string, error) {
+	var (
+		rdns  [][][2]string
+		rdn   [][2]string
+		typ   string
+		value []byte
+		inVal bool
+		keep  int // length of value up to its last escaped character
+	)
+	bad := func() ([][][2]string, error) {
+		return nil, fmt.Errorf("%w: DN %q", ErrInvalidName, dn)
+	}
+	end := func() {
+		v := strings.TrimRight(string(value), " ")
+		if len(v) < keep {
+			v = string(value[:keep])
+		}
+		rdn = append(rdn, [2]string{typ, v})
+		typ, value, inVal, keep = "", nil, false, 0
+	}
+	if strings.TrimSpace(dn) == "" {
+		return nil, nil
+	}
+	for i := 0; i < len(dn); i++ {
+		c := dn[i]
+		if !inVal {
+			if c == '=' {
+				inVal = true
+				for i+1 < len(dn) && dn[i+1] == ' ' {
+					i++
+				}
+				continue
+			}
+			if c == ',' || c == '+' {
+				return bad()
+			}
+			typ += string(c)
+			continue
+		}
+		switch c {
+		case '\\':
+			if i+1 >= len(dn) {
+				return bad()
+			}
+			if i+2 < len(dn) && xvalues[dn[i+1]] != 255 && xvalues[dn[i+2]] != 255 {
+				c, _ := xtob(dn[i+1], dn[i+2])
+				value = append(value, c)
+				i += 2
+			} else {
+				value = append(value, dn[i+1])
+				i++
+			}
+			keep = len(value)
+		case '+':
+			end()
+		case ',', ';':
+			end()
+			rdns = append(rdns, rdn)
+			rdn = nil
+		default:
+			value = append(value, c)
+		}
+	}
+	if !inVal {
+		return bad()
+	}
+	end()
+	return append(rdns, rdn), nil
+}
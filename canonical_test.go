@@ -0,0 +1,156 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"testing"
+)
+
+func TestPunycode(t *testing.T) {
+	for in, want := range map[string]string{
+		"bücher":  "bcher-kva",
+		"münchen": "mnchen-3ya",
+		"ü":       "tda",
+		"例え":      "r8jz45g",
+		"abc":     "abc-",
+	} {
+		if got := punycode(in); got != want {
+			t.Errorf("punycode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNewDNS(t *testing.T) {
+	want := NewSHA1(NameSpaceDNS, []byte("www.example.com"))
+	for _, name := range []string{"www.example.com", "WWW.Example.COM.", "www.example.com."} {
+		if got, err := NewDNS(name); err != nil || got != want {
+			t.Errorf("NewDNS(%q) = %s, %v, want %s", name, got, err, want)
+		}
+	}
+	want = NewSHA1(NameSpaceDNS, []byte("xn--bcher-kva.example"))
+	if got, err := NewDNS("Bücher.example"); err != nil || got != want {
+		t.Errorf("NewDNS(Bücher.example) = %s, %v, want %s", got, err, want)
+	}
+	for _, name := range []string{"", ".", "a..b", "\xff.com", string(make([]byte, 64)) + ".com"} {
+		if _, err := NewDNS(name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("NewDNS(%q) got error %v, want %v", name, err, ErrInvalidName)
+		}
+	}
+}
+
+func TestCanonicalURL(t *testing.T) {
+	for in, want := range map[string]string{
+		"HTTP://Example.com":                  "http://example.com/",
+		"http://example.com/":                 "http://example.com/",
+		"http://example.com:80/":              "http://example.com/",
+		"https://example.com:8443":            "https://example.com:8443/",
+		"http://example.com/a/./b/../c/%7e":   "http://example.com/a/c/~",
+		"http://example.com/%2f%3a?q=%3d#%7A": "http://example.com/%2F%3A?q=%3D#z",
+		"http://User@Example.com/":            "http://User@example.com/",
+		"http://[FE80::1]:80/":                "http://[fe80::1]/",
+		"http://bücher.example/":              "http://xn--bcher-kva.example/",
+		"file:///etc/hosts":                   "file:///etc/hosts",
+		"mailto:Someone@Example.com":          "mailto:Someone@Example.com",
+		"urn:ISBN:0-395-36341-1":              "urn:ISBN:0-395-36341-1",
+	} {
+		got, err := canonicalURL(in)
+		if err != nil || got != want {
+			t.Errorf("canonicalURL(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	a, _ := NewURL("HTTP://Example.com/")
+	b, _ := NewURL("http://example.com")
+	if a != b || a != NewSHA1(NameSpaceURL, []byte("http://example.com/")) {
+		t.Errorf("got %s and %s", a, b)
+	}
+	for _, in := range []string{"/relative", "http://example.com/%zz", "http://a..b/"} {
+		if _, err := NewURL(in); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("NewURL(%q) got error %v, want %v", in, err, ErrInvalidName)
+		}
+	}
+}
+
+func TestRemoveDotSegments(t *testing.T) {
+	// RFC 3986 section 5.2.4.
+	for in, want := range map[string]string{
+		"/a/b/c/./../../g":   "/a/g",
+		"mid/content=5/../6": "mid/6",
+		"/..":                "/",
+		"/a/..":              "/",
+		"":                   "",
+	} {
+		if got := removeDotSegments(in); got != want {
+			t.Errorf("removeDotSegments(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNewOID(t *testing.T) {
+	if got, err := NewOID("1.3.6.1"); err != nil || got != NewSHA1(NameSpaceOID, []byte("1.3.6.1")) {
+		t.Errorf("NewOID(1.3.6.1) = %s, %v", got, err)
+	}
+	for _, oid := range []string{"2.999.12345678901234567890", "0.39", "1.2.840.113549"} {
+		if _, err := NewOID(oid); err != nil {
+			t.Errorf("NewOID(%q): %v", oid, err)
+		}
+	}
+	for _, oid := range []string{"", "1", "1.", "1..2", "1.03", "3.1", "1.40", "1.2a", " 1.2"} {
+		if _, err := NewOID(oid); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("NewOID(%q) got error %v, want %v", oid, err, ErrInvalidName)
+		}
+	}
+}
+
+func TestNewX500(t *testing.T) {
+	const dn = "CN=Steve Kille,O=Isode Limited,C=GB"
+	want, err := NewX500(dn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, other := range []string{
+		"cn=Steve Kille, o=Isode Limited, c=GB",
+		"CN = Steve Kille ;O=Isode Limited;C=GB",
+		"2.5.4.3=Steve Kille,O=Isode\\20Limited,C=#13024742",
+	} {
+		if got, err := NewX500(other); err != nil || got != want {
+			t.Errorf("NewX500(%q) = %s, %v, want %s", other, got, err, want)
+		}
+	}
+
+	der, _ := x500DER(dn)
+	var seq pkix.RDNSequence
+	if _, err := asn1.Unmarshal(der, &seq); err != nil {
+		t.Fatal(err)
+	}
+	var name pkix.Name
+	name.FillFromRDNSequence(&seq)
+	if name.String() != dn {
+		t.Errorf("DER decodes as %q, want %q", name.String(), dn)
+	}
+
+	// Multi-valued RDNs are sorted, and escapes are kept.
+	a, err := NewX500("OU=Sales+CN=J. Smith,DC=example,DC=net")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := NewX500("CN=J. Smith+OU=Sales,DC=example,DC=net"); a != b {
+		t.Errorf("got %s and %s", a, b)
+	}
+	if b, _ := NewX500("CN=J. Smith\\+OU=Sales,DC=example,DC=net"); a == b {
+		t.Errorf("escaped + gave %s", b)
+	}
+	if c, _ := NewX500("CN=x\\ ,O=y"); c == Must(NewX500("CN=x,O=y")) {
+		t.Error("escaped trailing space was removed")
+	}
+
+	for _, dn := range []string{"CN", "CN=a,", "=a", "XX=a", "CN=a\\", "CN=#zz"} {
+		if _, err := NewX500(dn); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("NewX500(%q) got error %v, want %v", dn, err, ErrInvalidName)
+		}
+	}
+}
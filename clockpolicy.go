@@ -0,0 +1,182 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// A ClockPolicy selects what NewUUID, NewV6, NewV7 and the other generators
+// using the clock do when they cannot use a time after the last one they used
+// without running ahead of the clock.  For Version 1 and 6 UUIDs that happens
+// when the clock goes backwards.  For Version 7 UUIDs it also happens when the
+// 12 bit sequence of the current millisecond is used up.
+type ClockPolicy int
+
+const (
+	// ClockDefault increments the clock sequence of Version 1 and 6 UUIDs,
+	// and borrows time from the future for Version 7 UUIDs for as long as
+	// needed.  This is the behavior of earlier versions of this package.
+	ClockDefault ClockPolicy = iota
+
+	// ClockBorrow uses the time following the last time used, as long as
+	// it is no more than the maximum drift ahead of the clock, and returns
+	// ErrClockDrift otherwise.  The clock sequence is not changed.
+	ClockBorrow
+
+	// ClockBlock waits until the clock passes the last time used, unless
+	// that would take longer than the maximum drift, in which case
+	// ErrClockDrift is returned without waiting.
+	ClockBlock
+
+	// ClockError returns ErrClockBehind.
+	ClockError
+
+	// ClockReseed uses the time of the clock.  Version 1 and 6 UUIDs get a
+	// new random clock sequence.  Version 7 UUIDs restart the sequence from
+	// the clock, so they stay unique through their random bits but no
+	// longer sort after the UUIDs generated before.
+	ClockReseed
+)
+
+// ErrClockBehind is returned by the generators using the clock under
+// ClockError when the clock is behind the last time used.
+var ErrClockBehind = errors.New("clock is behind the last UUID time")
+
+// A ClockRegression reports that the clock went backwards.
+type ClockRegression struct {
+	Now  time.Time // the reading of the clock
+	Last time.Time // the earlier, but later, reading
+}
+
+var (
+	// Protected by timeMu.
+	clockPolicy    ClockPolicy
+	clockMaxDrift  = DefaultMaxClockDrift
+	regressionHook func(ClockRegression)
+	lastClock      int64 // last reading of the clock in Unix nanoseconds
+
+	clockRegressions uint64 // accessed atomically
+
+	timeSleep = time.Sleep // for testing
+)
+
+// SetClockPolicy sets the policy used when the clock is behind the last time
+// used.  maxDrift limits ClockBorrow and ClockBlock; DefaultMaxClockDrift is
+// used if it is 0 or negative.  The policy does not apply to NewV6WithTime, nor
+// to times taken from the shared clock of EnableSharedClock, which are always
+// borrowed.
+func SetClockPolicy(policy ClockPolicy, maxDrift time.Duration) {
+	if maxDrift <= 0 {
+		maxDrift = DefaultMaxClockDrift
+	}
+	defer timeMu.Unlock()
+	timeMu.Lock()
+	clockPolicy, clockMaxDrift = policy, maxDrift
+}
+
+// SetClockRegressionHook sets a function called each time the clock is seen
+// going backwards, for example to alert on NTP problems.  f is called while
+// the clock of the package is locked and must not generate time-based UUIDs.
+// A nil f removes the hook.
+func SetClockRegressionHook(f func(ClockRegression)) {
+	defer timeMu.Unlock()
+	timeMu.Lock()
+	regressionHook = f
+}
+
+// ClockRegressions returns the number of times the clock was seen going
+// backwards.
+func ClockRegressions() uint64 {
+	return atomic.LoadUint64(&clockRegressions)
+}
+
+// readClock returns the time, and whether the clock went backwards since it
+// was last read, which it reports.  timeMu must be held.
+func readClock() (t time.Time, back bool) {
+	t = timeNow()
+	n := t.UnixNano()
+	if back = n < lastClock; back {
+		atomic.AddUint64(&clockRegressions, 1)
+		if regressionHook != nil {
+			regressionHook(ClockRegression{Now: t, Last: time.Unix(0, lastClock)})
+		}
+	}
+	lastClock = n
+	return t, back
+}
+
+// v1Behind returns the Version 1 time to use, under clockPolicy, when the
+// clock, at now, is behind lasttime.  timeMu must be held; it is released
+// while blocking.
+func v1Behind(now uint64) (uint64, error) {
+	switch clockPolicy {
+	case ClockBorrow:
+		next := lasttime + 1
+		if time.Duration(next-now)*100 > clockMaxDrift {
+			return 0, ErrClockDrift
+		}
+		return next, nil
+	case ClockBlock:
+		for now <= lasttime {
+			wait := time.Duration(lasttime-now+1) * 100
+			if wait > clockMaxDrift {
+				return 0, ErrClockDrift
+			}
+			timeMu.Unlock()
+			timeSleep(wait)
+			timeMu.Lock()
+			now = uint64(timeNow().UnixNano()/100) + g1582ns100
+		}
+		return now, nil
+	case ClockError:
+		return 0, ErrClockBehind
+	case ClockReseed:
+		if err := setClockSequence(-1); err != nil {
+			return 0, err
+		}
+		lasttime = 0
+		return now, nil
+	}
+	return now, nil
+}
+
+// v7Behind returns the Version 7 time, as milli<<12 + seq, to use under
+// clockPolicy when the clock, at now, is a millisecond or more behind the
+// time following lastV7time.  timeMu must be held; it is released while
+// blocking.
+func v7Behind(now int64) (int64, error) {
+	next := lastV7time + 1
+	switch clockPolicy {
+	case ClockBorrow:
+		if time.Duration(next>>12-now>>12)*time.Millisecond > clockMaxDrift {
+			return 0, ErrClockDrift
+		}
+		return next, nil
+	case ClockBlock:
+		for next>>12 > now>>12 {
+			wait := time.Duration(next>>12-now>>12) * time.Millisecond
+			if wait > clockMaxDrift {
+				return 0, ErrClockDrift
+			}
+			timeMu.Unlock()
+			timeSleep(wait)
+			timeMu.Lock()
+			now = v7Time(timeNow().UnixNano())
+			next = lastV7time + 1
+		}
+		if now > lastV7time {
+			return now, nil
+		}
+		return next, nil
+	case ClockError:
+		return 0, ErrClockBehind
+	case ClockReseed:
+		return now, nil
+	}
+	return next, nil
+}
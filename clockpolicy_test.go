@@ -0,0 +1,234 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock replaces the clock with one set by the returned function, and
+// sleeping with advancing it by the time added to slept.  The state of the
+// package clock is restored when t finishes.
+func fakeClock(t *testing.T, start time.Time) (set func(time.Time), slept *time.Duration) {
+	timeMu.Lock()
+	v1, custom, seq, v7, last := lasttime, lastCustom, clockSeq, lastV7time, lastClock
+	lasttime, lastCustom, lastV7time, lastClock = 0, 0, 0, 0
+	timeMu.Unlock()
+
+	now := start
+	var total time.Duration
+	timeNow = func() time.Time { return now }
+	timeSleep = func(d time.Duration) { total += d; now = now.Add(d) }
+	t.Cleanup(func() {
+		timeNow, timeSleep = time.Now, time.Sleep
+		SetClockPolicy(ClockDefault, 0)
+		SetClockRegressionHook(nil)
+		timeMu.Lock()
+		lasttime, lastCustom, clockSeq, lastV7time, lastClock = v1, custom, seq, v7, last
+		timeMu.Unlock()
+	})
+	return func(t time.Time) { now = t }, &total
+}
+
+var policyStart = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestClockPolicyDefault(t *testing.T) {
+	set, _ := fakeClock(t, policyStart)
+	var got []ClockRegression
+	SetClockRegressionHook(func(r ClockRegression) { got = append(got, r) })
+	n := ClockRegressions()
+
+	u1 := Must(NewV7())
+	v1 := Must(NewUUID())
+	set(policyStart.Add(-time.Second))
+	if u2 := Must(NewV7()); Compare(u2, u1) <= 0 {
+		t.Errorf("%s sorts before %s", u2, u1)
+	}
+	if v2 := Must(NewUUID()); v2.ClockSequence() == v1.ClockSequence() {
+		t.Errorf("clock sequence %d not changed", v2.ClockSequence())
+	}
+
+	if len(got) != 1 || !got[0].Now.Equal(policyStart.Add(-time.Second)) || !got[0].Last.Equal(policyStart) {
+		t.Errorf("hook got %v", got)
+	}
+	if d := ClockRegressions() - n; d != 1 {
+		t.Errorf("got %d regressions, want 1", d)
+	}
+}
+
+func TestClockPolicyBorrow(t *testing.T) {
+	set, _ := fakeClock(t, policyStart)
+	SetClockPolicy(ClockBorrow, 10*time.Second)
+
+	u1 := Must(NewV7())
+	v1 := Must(NewUUID())
+	set(policyStart.Add(-5 * time.Second))
+	u2 := Must(NewV7())
+	if Compare(u2, u1) <= 0 {
+		t.Errorf("%s sorts before %s", u2, u1)
+	}
+	v2 := Must(NewUUID())
+	if v2.ClockSequence() != v1.ClockSequence() || v2.Time() != v1.Time()+1 {
+		t.Errorf("got time %d sequence %d, want %d and %d", v2.Time(), v2.ClockSequence(), v1.Time()+1, v1.ClockSequence())
+	}
+
+	set(policyStart.Add(-time.Minute))
+	if _, err := NewV7(); err != ErrClockDrift {
+		t.Errorf("NewV7 got error %v, want %v", err, ErrClockDrift)
+	}
+	if _, err := NewUUID(); err != ErrClockDrift {
+		t.Errorf("NewUUID got error %v, want %v", err, ErrClockDrift)
+	}
+}
+
+func TestClockPolicyBlock(t *testing.T) {
+	set, slept := fakeClock(t, policyStart)
+	SetClockPolicy(ClockBlock, 10*time.Second)
+
+	u1 := Must(NewV7())
+	set(policyStart.Add(-5 * time.Second))
+	u2 := Must(NewV7())
+	if Compare(u2, u1) <= 0 {
+		t.Errorf("%s sorts before %s", u2, u1)
+	}
+	if *slept < 5*time.Second {
+		t.Errorf("slept %v, want at least 5s", *slept)
+	}
+
+	v1 := Must(NewV6())
+	set(policyStart.Add(-5 * time.Second))
+	v2 := Must(NewV6())
+	if Compare(v2, v1) <= 0 || v2.ClockSequence() != v1.ClockSequence() {
+		t.Errorf("%s does not follow %s", v2, v1)
+	}
+
+	set(policyStart.Add(-time.Minute))
+	if _, err := NewV7(); err != ErrClockDrift {
+		t.Errorf("NewV7 got error %v, want %v", err, ErrClockDrift)
+	}
+}
+
+func TestClockPolicyError(t *testing.T) {
+	set, _ := fakeClock(t, policyStart)
+	SetClockPolicy(ClockError, 0)
+
+	Must(NewV7())
+	Must(NewUUID())
+	set(policyStart.Add(-time.Millisecond))
+	if _, err := NewV7(); err != ErrClockBehind {
+		t.Errorf("NewV7 got error %v, want %v", err, ErrClockBehind)
+	}
+	if _, err := NewUUID(); err != ErrClockBehind {
+		t.Errorf("NewUUID got error %v, want %v", err, ErrClockBehind)
+	}
+
+	// Running out of sequence numbers within a millisecond is an error too.
+	set(policyStart.Add(time.Second))
+	var err error
+	for i := 0; i < 4097 && err == nil; i++ {
+		_, err = NewV7()
+	}
+	if err != ErrClockBehind {
+		t.Errorf("got error %v after using up the sequence, want %v", err, ErrClockBehind)
+	}
+}
+
+func TestClockPolicyReseed(t *testing.T) {
+	set, _ := fakeClock(t, policyStart)
+	SetClockPolicy(ClockReseed, 0)
+
+	Must(NewV7())
+	set(policyStart.Add(-time.Second))
+	u := Must(NewV7())
+	if want := policyStart.Add(-time.Second).UnixMilli(); u.ULIDTime().UnixMilli() != want {
+		t.Errorf("%s: got time %v, want the clock", u, u.ULIDTime())
+	}
+
+	SetClockSequence(0x1234)
+	set(policyStart)
+	Must(NewUUID())
+	set(policyStart.Add(-time.Second))
+	v := Must(NewUUID())
+	if sec, _ := v.Time().UnixTime(); sec != policyStart.Unix()-1 {
+		t.Errorf("%s: got time %d, want the clock", v, sec)
+	}
+	if v.ClockSequence() == 0x1234 {
+		t.Errorf("%s: clock sequence not reseeded", v)
+	}
+}
+
+// TestClockPolicyCustomTime checks that a custom time ahead of the clock does
+// not make the clock look behind.
+func TestClockPolicyCustomTime(t *testing.T) {
+	for _, policy := range []ClockPolicy{ClockDefault, ClockBorrow, ClockBlock, ClockError, ClockReseed} {
+		_, slept := fakeClock(t, policyStart)
+		SetClockPolicy(policy, 0)
+		Must(NewUUID())
+		future := policyStart.Add(24 * time.Hour)
+		if _, err := NewV6WithTime(&future); err != nil {
+			t.Fatalf("policy %d: NewV6WithTime: %v", policy, err)
+		}
+		for _, f := range []func() (UUID, error){NewUUID, NewV6} {
+			uuid, err := f()
+			if err != nil {
+				t.Errorf("policy %d: got error %v", policy, err)
+				continue
+			}
+			if sec, _ := uuid.Time().UnixTime(); sec != policyStart.Unix() || *slept != 0 {
+				t.Errorf("policy %d: %s has time %d after sleeping %v, want %d", policy, uuid, sec, *slept, policyStart.Unix())
+			}
+		}
+	}
+
+	// Repeating a custom time still gives a new UUID.
+	past := policyStart.Add(-time.Hour)
+	if u1, u2 := Must(NewV6WithTime(&past)), Must(NewV6WithTime(&past)); u1 == u2 {
+		t.Errorf("NewV6WithTime returned %s twice", u1)
+	}
+}
+
+func TestTickCounter(t *testing.T) {
+	set, _ := fakeClock(t, policyStart)
+	EnableTickCounter()
+	defer DisableTickCounter()
+
+	last := Must(NewV6())
+	for i := 0; i < 100; i++ {
+		uuid := Must(NewV6())
+		if uuid.Time() != last.Time()+1 || uuid.ClockSequence() != last.ClockSequence() {
+			t.Fatalf("%s does not follow %s", uuid, last)
+		}
+		last = uuid
+	}
+
+	// The clock moving on, but not yet past the times used, is no regression.
+	set(policyStart.Add(50 * 100))
+	if uuid := Must(NewV6()); uuid.Time() != last.Time()+1 || uuid.ClockSequence() != last.ClockSequence() {
+		t.Errorf("%s does not follow %s", uuid, last)
+	}
+
+	set(policyStart.Add(-time.Second))
+	if uuid := Must(NewV6()); uuid.ClockSequence() == last.ClockSequence() {
+		t.Errorf("%s: clock sequence not changed after the clock went backwards", uuid)
+	}
+
+	// A custom time ahead of the clock does not move the tick counter.
+	set(policyStart.Add(time.Second))
+	future := timeNow().Add(24 * time.Hour)
+	Must(NewV6WithTime(&future))
+	for _, f := range []func() (UUID, error){NewUUID, NewV6} {
+		uuid := Must(f())
+		if sec, _ := uuid.Time().UnixTime(); sec != timeNow().Unix() {
+			t.Errorf("%s: got time %d, want %d", uuid, sec, timeNow().Unix())
+		}
+	}
+
+	DisableTickCounter()
+	v1 := Must(NewV6())
+	if v2 := Must(NewV6()); v2.Time() != v1.Time() || v2.ClockSequence() == v1.ClockSequence() {
+		t.Errorf("%s: clock sequence not changed on the same tick", v2)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !js
+// +build !js
+
+// Uuidd serves time-based UUIDs to the processes of a host over a Unix domain
+// socket, so that all of them share one clock sequence, Node ID and clock.
+// Clients use uuid.DaemonClient.
+//
+// Usage:
+//
+//	uuidd [-socket path] [-mode perm] [-node provider]
+//
+// The -node flag selects how the Node ID of Version 1 and 6 UUIDs is chosen:
+// "default", "interface" for uuid.InterfaceNode, or "random" for
+// uuid.RandomNode.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/google/uuid"
+)
+
+var (
+	socket = flag.String("socket", uuid.DefaultDaemonSocket, "path of the Unix domain socket to listen on")
+	mode   = flag.String("mode", "0666", "permissions of the socket")
+	node   = flag.String("node", "default", "node ID provider: default, interface or random")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("uuidd: ")
+	flag.Parse()
+	if flag.NArg() != 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	perm, err := strconv.ParseUint(*mode, 8, 32)
+	if err != nil {
+		log.Fatalf("invalid -mode %q", *mode)
+	}
+	if err := setNode(*node); err != nil {
+		log.Fatal(err)
+	}
+
+	l, err := listen(*socket, os.FileMode(perm))
+	if err != nil {
+		log.Fatal(err)
+	}
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sig
+		l.Close() // removes the socket
+	}()
+
+	err = uuid.ServeDaemon(l)
+	if !errors.Is(err, net.ErrClosed) {
+		log.Fatal(err)
+	}
+}
+
+// setNode sets the Node ID provider called name.
+func setNode(name string) error {
+	switch name {
+	case "default":
+		return nil
+	case "interface":
+		return uuid.SetNodeIDProvider(uuid.InterfaceNode{})
+	case "random":
+		return uuid.SetNodeIDProvider(uuid.RandomNode{})
+	}
+	return fmt.Errorf("unknown -node %q", name)
+}
+
+// listen listens on the Unix domain socket path, replacing a stale socket
+// left behind by a previous daemon.
+func listen(path string, perm os.FileMode) (net.Listener, error) {
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("another daemon is listening on %s", path)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, perm); err != nil {
+		l.Close()
+		return nil, err
+	}
+	return l, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// FromV1 returns the Version 1 UUID with time t, the lower 14 bits of the
+// clock sequence seq and the first 6 bytes of node, as returned by the Time,
+// ClockSequence and NodeID methods of a Version 1 UUID.  Unlike NewUUID it
+// does not use or change the clock, clock sequence or Node ID of the package.
+// A TimeRangeError is returned if t does not fit in a UUID, and an error if
+// node is shorter than 6 bytes.
+func FromV1(t Time, seq int, node []byte) (UUID, error) {
+	var uuid UUID
+	if err := checkFields(1, t, node); err != nil {
+		return uuid, err
+	}
+	putV1Time(&uuid, t, fieldSeq(seq))
+	copy(uuid[10:], node)
+	return uuid, nil
+}
+
+// FromV6 is like FromV1 but returns a Version 6 UUID.
+func FromV6(t Time, seq int, node []byte) (UUID, error) {
+	var uuid UUID
+	if err := checkFields(6, t, node); err != nil {
+		return uuid, err
+	}
+	putV6Time(&uuid, t, fieldSeq(seq))
+	copy(uuid[10:], node)
+	return uuid, nil
+}
+
+// FromDCESecurity returns the DCE Security (Version 2) UUID with domain and
+// id that NewDCESecurity would return for time t, clock sequence seq and the
+// Node ID node.  Version 2 UUIDs only keep the upper 28 bits of t and the upper
+// 6 bits of the 14 bit clock sequence, in place of which they hold the id and
+// the domain.
+func FromDCESecurity(domain Domain, id uint32, t Time, seq int, node []byte) (UUID, error) {
+	uuid, err := FromV1(t, seq, node)
+	if err != nil {
+		return uuid, err
+	}
+	uuid[6] = (uuid[6] & 0x0f) | 0x20 // Version 2
+	uuid[9] = byte(domain)
+	binary.BigEndian.PutUint32(uuid[0:], id)
+	return uuid, nil
+}
+
+// checkFields returns an error if t or node cannot be placed in a UUID of
+// version.
+func checkFields(version Version, t Time, node []byte) error {
+	if t < 0 || t > maxTime {
+		return TimeRangeError{Version: version, Time: time.Unix(t.UnixTime())}
+	}
+	if len(node) < 6 {
+		return fmt.Errorf("node ID too short (got %d bytes)", len(node))
+	}
+	return nil
+}
+
+// fieldSeq returns the clock sequence field holding the lower 14 bits of seq.
+func fieldSeq(seq int) uint16 {
+	return uint16(seq&0x3fff) | 0x8000 // Set our variant
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestFromFields(t *testing.T) {
+	for _, gen := range []func() (UUID, error){NewUUID, NewV6} {
+		want := Must(gen())
+		from := FromV1
+		if want.Version() == 6 {
+			from = FromV6
+		}
+		got, err := from(want.Time(), want.ClockSequence(), want.NodeID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("version %d: got %s, want %s", want.Version(), got, want)
+		}
+	}
+
+	// RFC 9562 appendix A.1 and A.5.
+	node := []byte{0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46}
+	ts := Time(0x1EC9414C232AB00)
+	if got := Must(FromV1(ts, 0x33c8, node)).String(); got != "c232ab00-9414-11ec-b3c8-9f6bdeced846" {
+		t.Errorf("FromV1 got %s", got)
+	}
+	if got := Must(FromV6(ts, 0x33c8, node)).String(); got != "1ec9414c-232a-6b00-b3c8-9f6bdeced846" {
+		t.Errorf("FromV6 got %s", got)
+	}
+
+	if _, err := FromV1(ts, 0, node[:5]); err == nil {
+		t.Error("FromV1 accepted a short node ID")
+	}
+	if _, err := FromV6(maxTime+1, 0, node); !errors.Is(err, ErrTimeRange) {
+		t.Errorf("got error %v, want %v", err, ErrTimeRange)
+	}
+	if _, err := FromV1(-1, 0, node); !errors.Is(err, ErrTimeRange) {
+		t.Errorf("got error %v, want %v", err, ErrTimeRange)
+	}
+}
+
+func TestFromDCESecurity(t *testing.T) {
+	want, err := NewDCESecurity(Group, 1234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := FromDCESecurity(Group, 1234, want.Time(), want.ClockSequence(), want.NodeID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Time and ClockSequence of a Version 2 UUID include the id and domain,
+	// which FromDCESecurity replaces again.
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if got.Version() != 2 || got.Domain() != Group || got.ID() != 1234 || !bytes.Equal(got.NodeID(), want.NodeID()) {
+		t.Errorf("%s: got version %d, domain %s, id %d", got, got.Version(), got.Domain(), got.ID())
+	}
+}
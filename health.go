@@ -0,0 +1,177 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// The parameters of the health tests of NIST SP 800-90B section 4.4 for bytes
+// of full entropy, with a false positive rate below 2^-64 per byte.  A failure
+// is kept, so the rate has to be low enough for no healthy generator to ever
+// fail in the lifetime of a process.
+const (
+	healthRepCutoff  = 9   // repetition count test, 1 + 64/8
+	healthWindow     = 512 // adaptive proportion test window
+	healthPropCutoff = 27  // adaptive proportion test
+	selfTestBytes    = 1024
+)
+
+// ErrRandHealth is returned, wrapped, when the bytes from the random number
+// generator fail a health test.
+var ErrRandHealth = errors.New("random number generator failed health test")
+
+var (
+	healthEnabled atomic.Bool
+	healthMu      sync.Mutex
+	health        healthTest // protected with healthMu
+)
+
+// EnableRandHealthTests enables continuous health tests of the random number
+// generator set by SetRand, in the style of NIST SP 800-90B section 4.4.  All
+// bytes read from it, including those for the randomness pool, clock
+// sequences and random Node IDs, pass through a repetition count test, which
+// fails when a byte repeats 9 times in a row, and an adaptive proportion test,
+// which fails when the first byte of a window of 512 bytes occurs 27 or more
+// times in the window.  A generator of uniformly random bytes fails either
+// with a probability below 2^-64 per byte: generating a million Version 4
+// UUIDs a second, a false alarm is expected once in over 30,000 years.  A
+// stuck generator, or one returning a fixed pattern or text, fails quickly.
+//
+// Once a test fails, every UUID generation function that reads from the
+// generator returns an error wrapping ErrRandHealth, or panics if it has no
+// error result, until the tests are enabled again or SetRand is called.
+// Tokens, which read crypto/rand directly, are not tested.
+func EnableRandHealthTests() {
+	defer healthMu.Unlock()
+	healthMu.Lock()
+	health = healthTest{}
+	healthEnabled.Store(true)
+}
+
+// DisableRandHealthTests disables the health tests enabled by
+// EnableRandHealthTests.
+func DisableRandHealthTests() {
+	healthEnabled.Store(false)
+}
+
+// resetRandHealth clears a failure of the health tests, for a new generator.
+func resetRandHealth() {
+	defer healthMu.Unlock()
+	healthMu.Lock()
+	health = healthTest{}
+}
+
+// RandSelfTest runs the start-up tests of NIST SP 800-90B section 4.3.  It
+// checks that the health tests detect a stuck generator, and then runs them on
+// 1024 bytes read from the generator set by SetRand, whether or not
+// EnableRandHealthTests was called.  A service should call RandSelfTest when
+// it starts and refuse to run if it returns an error.
+func RandSelfTest() error {
+	var h healthTest
+	if h.add(make([]byte, healthRepCutoff)) == nil {
+		return errors.New("uuid: repetition count test did not detect a stuck generator")
+	}
+	var b [selfTestBytes]byte
+	for i := range b {
+		b[i] = byte(i % 16)
+	}
+	if h = (healthTest{}); h.add(b[:]) == nil {
+		return errors.New("uuid: adaptive proportion test did not detect a repeating generator")
+	}
+	for i := range b {
+		b[i] = byte(i)
+	}
+	if h = (healthTest{}); h.add(b[:]) != nil {
+		return errors.New("uuid: health tests failed a uniform sequence")
+	}
+
+	if _, err := io.ReadFull(rander, b[:]); err != nil {
+		return err
+	}
+	h = healthTest{}
+	return h.add(b[:])
+}
+
+// randSource returns the random number generator set by SetRand, with the
+// health tests applied if they are enabled.
+func randSource() io.Reader {
+	if healthEnabled.Load() {
+		return healthReader{rander}
+	}
+	return rander
+}
+
+// A healthReader runs the health tests on the bytes read from r.
+type healthReader struct {
+	r io.Reader
+}
+
+func (h healthReader) Read(b []byte) (int, error) {
+	healthMu.Lock()
+	err := health.err
+	healthMu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	n, err := h.r.Read(b)
+	defer healthMu.Unlock()
+	healthMu.Lock()
+	if herr := health.add(b[:n]); herr != nil {
+		return 0, herr
+	}
+	return n, err
+}
+
+// A healthTest holds the state of the repetition count and adaptive
+// proportion tests.
+type healthTest struct {
+	err error // the first failure
+
+	last byte // repetition count test
+	run  int
+
+	first byte // adaptive proportion test
+	count int
+	n     int
+}
+
+// add runs the tests on the bytes b.  It returns the first failure, which is
+// also returned by every later call.
+func (h *healthTest) add(b []byte) error {
+	if h.err != nil {
+		return h.err
+	}
+	for _, x := range b {
+		if h.run > 0 && x == h.last {
+			h.run++
+		} else {
+			h.last, h.run = x, 1
+		}
+		if h.run >= healthRepCutoff {
+			h.err = fmt.Errorf("%w: byte %#02x repeated %d times", ErrRandHealth, x, h.run)
+			return h.err
+		}
+
+		switch {
+		case h.n == 0:
+			h.first, h.count = x, 1
+		case x == h.first:
+			h.count++
+		}
+		if h.count >= healthPropCutoff {
+			h.err = fmt.Errorf("%w: byte %#02x occurred %d times in %d bytes", ErrRandHealth, x, h.count, h.n+1)
+			return h.err
+		}
+		if h.n++; h.n == healthWindow {
+			h.n = 0
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestRandHealthTests(t *testing.T) {
+	EnableRandHealthTests()
+	defer func() {
+		DisableRandHealthTests()
+		DisableRandPool()
+		SetRand(nil)
+		SetClockSequence(-1)
+	}()
+
+	// crypto/rand passes.
+	for i := 0; i < 10000; i++ {
+		if _, err := NewRandom(); err != nil {
+			t.Fatalf("NewRandom #%d: %v", i, err)
+		}
+	}
+
+	zeros := bytes.NewReader(make([]byte, 1<<16))
+	for _, tt := range []struct {
+		name string
+		rand io.Reader
+		pool bool
+		f    func() (UUID, error)
+	}{
+		// badRand returns 0, 1, 2, ... for each read, which only the
+		// adaptive proportion test detects.
+		{"NewRandom", badRand{}, false, NewRandom},
+		{"NewRandomInRange", badRand{}, false, func() (UUID, error) { return NewRandomInRange(UUIDRange{Start: Nil, End: Max}) }},
+		{"NewRandom pool", zeros, true, NewRandom},
+		{"NewUUID", zeros, false, func() (UUID, error) {
+			SetClockSequence(-1)
+			return NewUUID()
+		}},
+	} {
+		SetRand(tt.rand)
+		if tt.pool {
+			EnableRandPool()
+		}
+		var err error
+		for i := 0; i < 100 && err == nil; i++ {
+			_, err = tt.f()
+		}
+		if !errors.Is(err, ErrRandHealth) {
+			t.Errorf("%s: got error %v, want ErrRandHealth", tt.name, err)
+		}
+		if _, err = tt.f(); !errors.Is(err, ErrRandHealth) {
+			t.Errorf("%s: failure not kept, got error %v", tt.name, err)
+		}
+		DisableRandPool()
+	}
+
+	SetRand(nil)
+	if _, err := NewUUID(); err != nil {
+		t.Errorf("NewUUID after SetRand: %v", err)
+	}
+}
+
+func TestRandSelfTest(t *testing.T) {
+	defer SetRand(nil)
+	if err := RandSelfTest(); err != nil {
+		t.Fatal(err)
+	}
+	SetRand(badRand{})
+	if err := RandSelfTest(); err != nil {
+		t.Errorf("badRand failed: %v", err) // a single read of 0 to 255 repeated passes
+	}
+	SetRand(bytes.NewReader(bytes.Repeat([]byte("0123456789abcdef"), 64)))
+	if err := RandSelfTest(); !errors.Is(err, ErrRandHealth) {
+		t.Errorf("pattern: got error %v, want ErrRandHealth", err)
+	}
+	SetRand(bytes.NewReader(nil))
+	if err := RandSelfTest(); err == nil {
+		t.Error("empty generator passed")
+	}
+}
+
+func TestRandHealthCutoffs(t *testing.T) {
+	var h healthTest
+	if err := h.add(append(make([]byte, healthRepCutoff-1), 1)); err != nil {
+		t.Errorf("run of %d failed: %v", healthRepCutoff-1, err)
+	}
+	b := make([]byte, healthWindow)
+	for i := range b {
+		b[i] = byte(i % 255) // never 0xff
+	}
+	for i := 0; i < healthPropCutoff-1; i++ {
+		b[i*16] = 0xff
+	}
+	if h = (healthTest{}); h.add(b) != nil {
+		t.Errorf("%d occurrences in a window failed", healthPropCutoff-1)
+	}
+	b[(healthPropCutoff-1)*16] = 0xff
+	if h = (healthTest{}); h.add(b) == nil {
+		t.Errorf("%d occurrences in a window passed", healthPropCutoff)
+	}
+}
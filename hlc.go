@@ -0,0 +1,112 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// DefaultMaxClockDrift is the MaxDrift of an HLC whose MaxDrift is 0.
+const DefaultMaxClockDrift = time.Minute
+
+var (
+	// ErrClockDrift is returned, wrapped, by HLC.Observe when a UUID is
+	// from too far in the future, and by the generators using the clock
+	// under ClockBorrow and ClockBlock when the last time used is too far
+	// ahead of the clock.
+	ErrClockDrift = errors.New("UUID time too far ahead of local clock")
+
+	// ErrNotTimeOrdered is returned by HLC.Observe for UUIDs other than
+	// Version 7.
+	ErrNotTimeOrdered = errors.New("UUID is not Version 7")
+)
+
+// An HLC generates Version 7 UUIDs from a hybrid logical clock.  Its time is
+// the greater of the local clock and the time of every UUID passed to
+// Observe, so a UUID it generates after observing a UUID from another host
+// sorts after that UUID even if the clock of the other host is ahead.  As
+// with NewV7, the 12 bit rand_a field counts UUIDs within a millisecond.
+//
+// Observing UUIDs from hosts whose clock is far ahead would drag the clock
+// along, so Observe refuses UUIDs more than MaxDrift ahead of the local
+// clock.
+//
+// The zero value is ready to use.  An HLC is safe for concurrent use.
+type HLC struct {
+	// MaxDrift is how far ahead of the local clock an observed UUID may
+	// be.  DefaultMaxClockDrift is used if it is 0, and there is no limit
+	// if it is negative.
+	MaxDrift time.Duration
+
+	mu   sync.Mutex
+	last int64 // milli<<12 + seq of the last UUID generated or observed
+}
+
+// Observe folds the time of the Version 7 UUID uuid, received from another
+// host, into the clock of c, so that all UUIDs c generates from now on sort
+// after it.  If uuid is more than MaxDrift ahead of the local clock the clock
+// is not changed and an error wrapping ErrClockDrift is returned.
+func (c *HLC) Observe(uuid UUID) error {
+	if uuid.Version() != 7 || uuid.Variant() != RFC4122 {
+		return ErrNotTimeOrdered
+	}
+	hi, _ := uuid.Uint64Pair()
+	t := int64(hi>>16)<<12 | int64(hi&0xfff)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.MaxDrift >= 0 {
+		drift := c.MaxDrift
+		if drift == 0 {
+			drift = DefaultMaxClockDrift
+		}
+		now := timeNow()
+		if ahead := time.UnixMilli(t >> 12).Sub(now); ahead > drift {
+			return fmt.Errorf("%w: %s is %v ahead", ErrClockDrift, uuid, ahead)
+		}
+	}
+	if t > c.last {
+		c.last = t
+	}
+	return nil
+}
+
+// New returns a Version 7 UUID that sorts after every UUID c returned or
+// observed before.  Uses the randomness pool if it was enabled with
+// EnableRandPool.  On error, New returns Nil and an error.
+func (c *HLC) New() (UUID, error) {
+	uuid, err := NewRandom()
+	if err != nil {
+		return Nil, err
+	}
+	c.makeV7(uuid[:])
+	return uuid, nil
+}
+
+// NewFromReader is like New but reads its random bits from r.
+func (c *HLC) NewFromReader(r io.Reader) (UUID, error) {
+	uuid, err := NewRandomFromReader(r)
+	if err != nil {
+		return Nil, err
+	}
+	c.makeV7(uuid[:])
+	return uuid, nil
+}
+
+// makeV7 fills the time of a Version 7 UUID from the clock of c.
+func (c *HLC) makeV7(uuid []byte) {
+	c.mu.Lock()
+	now := v7Time(timeNow().UnixNano())
+	if now <= c.last {
+		now = c.last + 1
+	}
+	c.last = now
+	c.mu.Unlock()
+	putV7Time(uuid, now>>12, now&0xfff)
+}
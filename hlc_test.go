@@ -0,0 +1,66 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHLC(t *testing.T) {
+	var c HLC
+	u1 := Must(c.New())
+	if v := u1.Version(); v != 7 {
+		t.Errorf("%s: version %s expected 7", u1, v)
+	}
+
+	// A peer whose clock is 10 seconds ahead.
+	_, peer := v7At(time.Now().Add(10 * time.Second).UnixMilli())
+	if err := c.Observe(peer); err != nil {
+		t.Fatalf("Observe: %v", err)
+	}
+	u2 := Must(c.New())
+	if Compare(u2, peer) <= 0 {
+		t.Errorf("%s sorts before observed %s", u2, peer)
+	}
+	if u3 := Must(c.New()); Compare(u3, u2) <= 0 {
+		t.Errorf("%s sorts before %s", u3, u2)
+	}
+
+	// Observing an older UUID does not move the clock back.
+	if err := c.Observe(u1); err != nil {
+		t.Fatalf("Observe: %v", err)
+	}
+	if u4 := Must(c.New()); Compare(u4, u2) <= 0 {
+		t.Errorf("%s sorts before %s", u4, u2)
+	}
+}
+
+func TestHLCDrift(t *testing.T) {
+	_, ahead := v7At(time.Now().Add(2 * DefaultMaxClockDrift).UnixMilli())
+
+	var c HLC
+	if err := c.Observe(ahead); !errors.Is(err, ErrClockDrift) {
+		t.Errorf("got error %v, want %v", err, ErrClockDrift)
+	}
+	if uuid := Must(c.New()); Compare(uuid, ahead) >= 0 {
+		t.Errorf("refused UUID %s moved the clock to %s", ahead, uuid)
+	}
+
+	c = HLC{MaxDrift: 3 * DefaultMaxClockDrift}
+	if err := c.Observe(ahead); err != nil {
+		t.Errorf("Observe within MaxDrift: %v", err)
+	}
+	c = HLC{MaxDrift: -1}
+	_, far := v7At(time.Now().Add(24 * time.Hour).UnixMilli())
+	if err := c.Observe(far); err != nil {
+		t.Errorf("Observe without a limit: %v", err)
+	}
+
+	if err := c.Observe(New()); err != ErrNotTimeOrdered {
+		t.Errorf("got error %v, want %v", err, ErrNotTimeOrdered)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Files holding host identities on Linux, variables for testing.
+var (
+	machineIDPaths  = []string{"/etc/machine-id", "/var/lib/dbus/machine-id"}
+	bootIDPath      = "/proc/sys/kernel/random/boot_id"
+	productUUIDPath = "/sys/class/dmi/id/product_uuid"
+)
+
+// MachineID returns the machine ID of the host as found in /etc/machine-id,
+// or /var/lib/dbus/machine-id if the former does not exist.  See
+// machine-id(5).  The machine ID is a secret of the host: use
+// AppSpecificMachineID rather than exposing it.
+func MachineID() (UUID, error) {
+	var err error
+	for _, path := range machineIDPaths {
+		var uuid UUID
+		if uuid, err = readHostID(path); err == nil {
+			return uuid, nil
+		}
+		if !os.IsNotExist(err) {
+			break
+		}
+	}
+	return Nil, err
+}
+
+// BootID returns the ID of the current boot of the host, as found in
+// /proc/sys/kernel/random/boot_id.  A new boot ID is generated by the kernel
+// every time the host boots.
+func BootID() (UUID, error) {
+	return readHostID(bootIDPath)
+}
+
+// ProductUUID returns the UUID of the host as reported by its firmware in
+// /sys/class/dmi/id/product_uuid.  Reading it usually requires root.
+//
+// The byte order of the first three fields of the product UUID is not
+// consistent between firmwares.  Linux assumes they are little-endian, as SMBIOS
+// 2.6 and later specify, but some firmwares store them big-endian.  Use
+// MixedEndian to compare the result with a product UUID reported by a system
+// that made the other choice.
+func ProductUUID() (UUID, error) {
+	return readHostID(productUUIDPath)
+}
+
+// readHostID returns the ID held in the file at path, which must not be Nil.
+func readHostID(path string) (UUID, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Nil, err
+	}
+	uuid, err := Parse(strings.TrimSpace(string(data)))
+	if err != nil {
+		return Nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if uuid == Nil {
+		return Nil, fmt.Errorf("%s: ID is not set", path)
+	}
+	return uuid, nil
+}
+
+// MixedEndian returns uuid with the byte order of its first three fields
+// reversed.  It converts between the big-endian layout of RFC 9562 and the
+// mixed-endian layout used by Microsoft GUIDs and SMBIOS, in either direction.
+func MixedEndian(uuid UUID) UUID {
+	uuid[0], uuid[1], uuid[2], uuid[3] = uuid[3], uuid[2], uuid[1], uuid[0]
+	uuid[4], uuid[5] = uuid[5], uuid[4]
+	uuid[6], uuid[7] = uuid[7], uuid[6]
+	return uuid
+}
+
+// AppSpecificID returns an ID derived from the host ID id and the application
+// ID app, the way systemd's sd_id128_get_machine_app_specific(3) does: the
+// first 16 bytes of the HMAC-SHA256 of app keyed with id, stamped as a Random
+// (Version 4) UUID.  The host ID cannot be recovered from the result, so it
+// is safe to expose, and different applications get unrelated IDs.
+func AppSpecificID(id, app UUID) UUID {
+	mac := hmac.New(sha256.New, id[:])
+	mac.Write(app[:]) //nolint:errcheck
+	var uuid UUID
+	copy(uuid[:], mac.Sum(nil))
+	uuid[6] = (uuid[6] & 0x0f) | 0x40 // Version 4
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant is 10
+	return uuid
+}
+
+// AppSpecificMachineID returns the application specific ID of the host for
+// app.  It is the same as calling AppSpecificID with the result of MachineID,
+// and matches sd_id128_get_machine_app_specific.
+func AppSpecificMachineID(app UUID) (UUID, error) {
+	id, err := MachineID()
+	if err != nil {
+		return Nil, err
+	}
+	return AppSpecificID(id, app), nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeHostFiles points the host ID paths at files in a temporary directory
+// holding the given contents, and returns a function restoring them.
+func writeHostFiles(t *testing.T, machineID, bootID, productUUID string) func() {
+	dir := t.TempDir()
+	oldMachine, oldBoot, oldProduct := machineIDPaths, bootIDPath, productUUIDPath
+	machineIDPaths = []string{filepath.Join(dir, "machine-id"), filepath.Join(dir, "dbus-machine-id")}
+	bootIDPath = filepath.Join(dir, "boot_id")
+	productUUIDPath = filepath.Join(dir, "product_uuid")
+	for path, data := range map[string]string{
+		machineIDPaths[1]: machineID,
+		bootIDPath:        bootID,
+		productUUIDPath:   productUUID,
+	} {
+		if err := os.WriteFile(path, []byte(data), 0o444); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return func() {
+		machineIDPaths, bootIDPath, productUUIDPath = oldMachine, oldBoot, oldProduct
+	}
+}
+
+func TestHostIDs(t *testing.T) {
+	defer writeHostFiles(t,
+		"0123456789abcdef0123456789abcdef\n",
+		"f47ac10b-58cc-4372-8567-0e02b2c3d479\n",
+		"4C4C4544-0042-3510-8052-B3C04F4E5A31\n",
+	)()
+
+	for _, tt := range []struct {
+		name string
+		f    func() (UUID, error)
+		want string
+	}{
+		{"MachineID", MachineID, "01234567-89ab-cdef-0123-456789abcdef"},
+		{"BootID", BootID, "f47ac10b-58cc-4372-8567-0e02b2c3d479"},
+		{"ProductUUID", ProductUUID, "4c4c4544-0042-3510-8052-b3c04f4e5a31"},
+	} {
+		uuid, err := tt.f()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if uuid.String() != tt.want {
+			t.Errorf("%s got %s, want %s", tt.name, uuid, tt.want)
+		}
+	}
+
+	if err := os.WriteFile(bootIDPath, []byte("00000000000000000000000000000000\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := BootID(); err == nil {
+		t.Error("BootID accepted a Nil ID")
+	}
+	machineIDPaths = machineIDPaths[:1]
+	if _, err := MachineID(); !os.IsNotExist(err) {
+		t.Errorf("MachineID got error %v, want a not exist error", err)
+	}
+}
+
+func TestMixedEndian(t *testing.T) {
+	uuid := MustParse("00112233-4455-6677-8899-aabbccddeeff")
+	want := MustParse("33221100-5544-7766-8899-aabbccddeeff")
+	if got := MixedEndian(uuid); got != want {
+		t.Errorf("MixedEndian got %s, want %s", got, want)
+	}
+	if got := MixedEndian(want); got != uuid {
+		t.Errorf("MixedEndian got %s, want %s", got, uuid)
+	}
+}
+
+func TestAppSpecificID(t *testing.T) {
+	machine := MustParse("0123456789abcdef0123456789abcdef")
+	app := MustParse("f03daaeb-1c33-4b43-a732-172944bf772e")
+	want := MustParse("9def46ca-af6f-4bb1-ae1a-1a51f63f1702")
+	if got := AppSpecificID(machine, app); got != want {
+		t.Errorf("AppSpecificID got %s, want %s", got, want)
+	}
+
+	defer writeHostFiles(t, machine.String(), "", "")()
+	got, err := AppSpecificMachineID(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("AppSpecificMachineID got %s, want %s", got, want)
+	}
+	if v := got.Version(); v != 4 {
+		t.Errorf("%s: version %s expected 4", got, v)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+)
+
+// FromInt64Pair returns the UUID whose most significant 64 bits are msb and
+// whose least significant 64 bits are lsb.  It is the inverse of Int64Pair and
+// matches the java.util.UUID(long mostSigBits, long leastSigBits) constructor.
+func FromInt64Pair(msb, lsb int64) UUID {
+	var uuid UUID
+	binary.BigEndian.PutUint64(uuid[0:], uint64(msb))
+	binary.BigEndian.PutUint64(uuid[8:], uint64(lsb))
+	return uuid
+}
+
+// Int64Pair returns the most and least significant 64 bits of uuid as signed
+// integers, as returned by getMostSignificantBits and getLeastSignificantBits
+// of java.util.UUID.
+func (uuid UUID) Int64Pair() (msb, lsb int64) {
+	msb = int64(binary.BigEndian.Uint64(uuid[0:]))
+	lsb = int64(binary.BigEndian.Uint64(uuid[8:]))
+	return msb, lsb
+}
+
+// CompareJava compares a and b the way java.util.UUID.compareTo does.  The
+// result will be 0 if a == b, -1 if a < b, and +1 if a > b.
+//
+// Java compares the most and then the least significant 64 bits as signed
+// integers, so any UUID with the top bit of either half set sorts before one
+// with it cleared.  The result therefore differs from Compare, which orders
+// UUIDs lexicographically.  Use CompareJava only where the order must agree
+// with a Java service.
+func CompareJava(a, b UUID) int {
+	am, al := a.Int64Pair()
+	bm, bl := b.Int64Pair()
+	switch {
+	case am < bm:
+		return -1
+	case am > bm:
+		return 1
+	case al < bl:
+		return -1
+	case al > bl:
+		return 1
+	}
+	return 0
+}
+
+// JavaHashCode returns the value java.util.UUID.hashCode would return for
+// uuid.
+func (uuid UUID) JavaHashCode() int32 {
+	msb, lsb := uuid.Int64Pair()
+	hilo := msb ^ lsb
+	return int32(hilo>>32) ^ int32(hilo)
+}
+
+// NameUUIDFromBytes returns a new MD5 (Version 3) UUID computed from name
+// alone, without a name space.  It returns the same UUID as
+// java.util.UUID.nameUUIDFromBytes, which differs from NewMD5 in that no name
+// space is hashed before name.
+func NameUUIDFromBytes(name []byte) UUID {
+	uuid := UUID(md5.Sum(name))
+	uuid[6] = (uuid[6] & 0x0f) | 0x30 // Version 3
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant is 10
+	return uuid
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestInt64Pair(t *testing.T) {
+	for _, tt := range []struct {
+		in       string
+		msb, lsb int64
+	}{
+		{"00000000-0000-0000-0000-000000000000", 0, 0},
+		{"00000000-0000-0001-0000-000000000002", 1, 2},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", -1, -1},
+		{"f47ac10b-58cc-4372-8567-0e02b2c3d479", -0x0b853ef4a733bc8e, -0x7a98f1fd4d3c2b87},
+	} {
+		uuid := MustParse(tt.in)
+		msb, lsb := uuid.Int64Pair()
+		if msb != tt.msb || lsb != tt.lsb {
+			t.Errorf("%s: got (%d, %d), want (%d, %d)", tt.in, msb, lsb, tt.msb, tt.lsb)
+		}
+		if got := FromInt64Pair(tt.msb, tt.lsb); got != uuid {
+			t.Errorf("FromInt64Pair(%d, %d) got %s, want %s", tt.msb, tt.lsb, got, uuid)
+		}
+	}
+}
+
+func TestCompareJava(t *testing.T) {
+	// The order java.util.UUID.compareTo sorts these in.
+	want := []UUID{
+		MustParse("80000000-0000-0000-0000-000000000000"),
+		MustParse("ffffffff-ffff-ffff-8000-000000000000"),
+		MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff"),
+		MustParse("ffffffff-ffff-ffff-0000-000000000000"),
+		MustParse("00000000-0000-0000-0000-000000000000"),
+		MustParse("7fffffff-ffff-ffff-ffff-ffffffffffff"),
+	}
+	got := append([]UUID(nil), want...)
+	sort.Slice(got, func(i, j int) bool { return Compare(got[i], got[j]) < 0 })
+	sort.Slice(got, func(i, j int) bool { return CompareJava(got[i], got[j]) < 0 })
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("#%d: got %s, want %s", i, got[i], want[i])
+		}
+	}
+	if c := CompareJava(want[0], want[0]); c != 0 {
+		t.Errorf("CompareJava of equal UUIDs got %d, want 0", c)
+	}
+}
+
+func TestJavaHashCode(t *testing.T) {
+	for _, tt := range []struct {
+		msb, lsb int64
+		want     int32
+	}{
+		{0, 0, 0},
+		{1, 2, 3},
+		{-1, 0, 0},
+		{0x123456789abcdef0, 0, 0x12345678 ^ -0x65432110},
+	} {
+		if got := FromInt64Pair(tt.msb, tt.lsb).JavaHashCode(); got != tt.want {
+			t.Errorf("JavaHashCode(%d, %d) got %d, want %d", tt.msb, tt.lsb, got, tt.want)
+		}
+	}
+}
+
+func TestNameUUIDFromBytes(t *testing.T) {
+	// Values returned by java.util.UUID.nameUUIDFromBytes.
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{"hello", "5d41402a-bc4b-3a76-b971-9d911017c592"},
+		{"", "d41d8cd9-8f00-3204-a980-0998ecf8427e"},
+	} {
+		got := NameUUIDFromBytes([]byte(tt.in))
+		if got.String() != tt.want {
+			t.Errorf("NameUUIDFromBytes(%q) got %s, want %s", tt.in, got, tt.want)
+		}
+		if v := got.Version(); v != 3 {
+			t.Errorf("%s: version %s expected 3", got, v)
+		}
+	}
+}
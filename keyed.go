@@ -0,0 +1,126 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// A KeyedNamer generates name-based version 8 UUIDs with HMAC-SHA-256 under a
+// secret key.  Unlike the UUIDs of NewSHA1, which anyone can compute for a
+// guessed name such as an email address, they cannot be computed, nor the
+// name found by trying names, without the key, so they can serve as
+// pseudonyms.  The UUID of a name is the HMAC of Space followed by the name,
+// laid out as:
+//
+//	 0                   1                   2                   3
+//	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|                           hmac[0:4]                           |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|           hmac[4:6]           |  ver  |  tag  | key ID | hmac |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|var|                        hmac[8:16]                         |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|                           hmac[8:16]                          |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// ver is 8, tag is 4 and var is 10.  The top bits of byte 7 may hold a key ID
+// and its other bits hmac[7], so a UUID tells which key generated it when keys
+// are rotated.  Each bit of key ID takes a bit of the HMAC, of which 118 bits
+// are kept without a key ID.
+//
+// A KeyedNamer is created with NewKeyedNamer and is safe for concurrent use.
+// The zero KeyedNamer has no key and returns Nil for every name.
+type KeyedNamer struct {
+	key       []byte
+	space     UUID
+	keyID     uint8
+	keyIDBits int
+}
+
+// NewKeyedNamer returns a KeyedNamer of UUIDs in the name space space under
+// the secret key key, which should be at least 32 random bytes.  keyIDBits is
+// the number of bits of keyID placed in the UUIDs, from 0 to 8, and keyID must
+// fit in them.  With 0 no key ID is placed.  An error is returned if key is
+// empty or keyID or keyIDBits is invalid.
+func NewKeyedNamer(key []byte, space UUID, keyID uint8, keyIDBits int) (*KeyedNamer, error) {
+	if len(key) == 0 {
+		return nil, errors.New("empty KeyedNamer key")
+	}
+	if keyIDBits < 0 || keyIDBits > 8 {
+		return nil, fmt.Errorf("invalid key ID length %d", keyIDBits)
+	}
+	if uint(keyID)>>uint(keyIDBits) != 0 {
+		return nil, fmt.Errorf("key ID %d does not fit in %d bits", keyID, keyIDBits)
+	}
+	return &KeyedNamer{
+		key:       append([]byte(nil), key...),
+		space:     space,
+		keyID:     keyID,
+		keyIDBits: keyIDBits,
+	}, nil
+}
+
+// Space returns the name space of k.
+func (k *KeyedNamer) Space() UUID {
+	return k.space
+}
+
+// New returns the UUID of the name data.
+func (k *KeyedNamer) New(data []byte) UUID {
+	if len(k.key) == 0 {
+		return Nil
+	}
+	h := k.hash()
+	h.Write(data) //nolint:errcheck
+	return k.uuid(h.Sum(nil))
+}
+
+// NewString is like New but takes the name as a string.
+func (k *KeyedNamer) NewString(name string) UUID {
+	if len(k.key) == 0 {
+		return Nil
+	}
+	h := k.hash()
+	io.WriteString(h, name) //nolint:errcheck
+	return k.uuid(h.Sum(nil))
+}
+
+// hash returns an HMAC under the key of k that its name space has been
+// written to.
+func (k *KeyedNamer) hash() hash.Hash {
+	h := hmac.New(sha256.New, k.key)
+	h.Write(k.space[:]) //nolint:errcheck
+	return h
+}
+
+// uuid returns the UUID laid out from the HMAC sum.
+func (k *KeyedNamer) uuid(sum []byte) UUID {
+	var uuid UUID
+	copy(uuid[:], sum)
+	uuid[6] = 0x80 | v8Keyed // Version 8
+	if k.keyIDBits > 0 {
+		shift := uint(8 - k.keyIDBits)
+		uuid[7] = k.keyID<<shift | uuid[7]&(1<<shift-1)
+	}
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant is 10
+	return uuid
+}
+
+// KeyID returns the key ID in the top bits bits of a UUID generated by a
+// KeyedNamer whose key ID takes bits bits.  ok is false if uuid was not
+// generated by a KeyedNamer.
+func (uuid UUID) KeyID(bits int) (id uint8, ok bool) {
+	if uuid.v8Tag() != v8Keyed || bits < 0 || bits > 8 {
+		return 0, false
+	}
+	return uuid[7] >> uint(8-bits), true
+}
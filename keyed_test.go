@@ -0,0 +1,102 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"testing"
+)
+
+func TestKeyedNamer(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	k, err := NewKeyedNamer(key, NameSpaceDNS, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key[0] = 'x' // the key is copied
+	uuid := k.NewString("alice@example.com")
+	if uuid.Version() != 8 || uuid.Variant() != RFC4122 {
+		t.Errorf("%s: version %s, variant %s", uuid, uuid.Version(), uuid.Variant())
+	}
+	if uuid != k.New([]byte("alice@example.com")) {
+		t.Error("New and NewString differ")
+	}
+	mac := hmac.New(sha256.New, []byte("0123456789abcdef0123456789abcdef"))
+	mac.Write(NameSpaceDNS[:])
+	mac.Write([]byte("alice@example.com"))
+	sum := mac.Sum(nil)
+	want := sum[:16]
+	want[6] = 0x84
+	want[8] = want[8]&0x3f | 0x80
+	if string(uuid[:]) != string(want) {
+		t.Errorf("got %x, want %x", uuid[:], want)
+	}
+	if _, ok := uuid.KeyID(0); !ok {
+		t.Error("KeyID not ok")
+	}
+	if uuid.v8HasTime() {
+		t.Error("keyed UUID has a time")
+	}
+
+	other, _ := NewKeyedNamer([]byte("another key of at least 32 bytes"), NameSpaceDNS, 0, 0)
+	if other.NewString("alice@example.com") == uuid {
+		t.Error("different keys gave the same UUID")
+	}
+	other, _ = NewKeyedNamer([]byte("0123456789abcdef0123456789abcdef"), NameSpaceURL, 0, 0)
+	if other.NewString("alice@example.com") == uuid || other.Space() != NameSpaceURL {
+		t.Error("different name spaces gave the same UUID")
+	}
+
+	var zero KeyedNamer
+	if u := zero.NewString("alice@example.com"); u != Nil {
+		t.Errorf("zero KeyedNamer returned %s, want Nil", u)
+	}
+	if u := zero.New(nil); u != Nil {
+		t.Errorf("zero KeyedNamer returned %s, want Nil", u)
+	}
+}
+
+func TestKeyedNamerKeyID(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	for bits := 1; bits <= 8; bits++ {
+		id := uint8(1<<uint(bits) - 1)
+		k, err := NewKeyedNamer(key, NameSpaceDNS, id, bits)
+		if err != nil {
+			t.Fatalf("%d bits: %v", bits, err)
+		}
+		uuid := k.NewString("bob@example.com")
+		if got, ok := uuid.KeyID(bits); !ok || got != id {
+			t.Errorf("%d bits: got key ID %d %v, want %d", bits, got, ok, id)
+		}
+		if uuid.Version() != 8 || uuid.Variant() != RFC4122 {
+			t.Errorf("%s: version %s, variant %s", uuid, uuid.Version(), uuid.Variant())
+		}
+		k, _ = NewKeyedNamer(key, NameSpaceDNS, 0, bits)
+		if got, _ := k.NewString("bob@example.com").KeyID(bits); got != 0 {
+			t.Errorf("%d bits: got key ID %d, want 0", bits, got)
+		}
+	}
+	if _, ok := New().KeyID(4); ok {
+		t.Error("KeyID of a random UUID ok")
+	}
+
+	for _, tt := range []struct {
+		key  []byte
+		id   uint8
+		bits int
+	}{
+		{nil, 0, 0},
+		{[]byte{}, 0, 0},
+		{key, 16, 4},
+		{key, 1, 0},
+		{key, 0, 9},
+		{key, 0, -1},
+	} {
+		if k, err := NewKeyedNamer(tt.key, NameSpaceDNS, tt.id, tt.bits); err == nil {
+			t.Errorf("NewKeyedNamer(%q, %d, %d) = %v, want an error", tt.key, tt.id, tt.bits, k)
+		}
+	}
+}
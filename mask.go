@@ -0,0 +1,173 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// maskRounds is the number of Feistel rounds of a Cipher.
+const maskRounds = 10
+
+// ErrNoMaskCipher is returned when a Masked UUID is marshaled or unmarshaled
+// before SetMaskCipher is called.
+var ErrNoMaskCipher = errors.New("no mask cipher set")
+
+// A Cipher is a keyed permutation that masks UUIDs of one version, such as
+// Version 7 UUIDs whose timestamps tell when they were created and how many
+// were created, as random-looking Version 4 UUIDs, and unmasks them again.
+// Only the key holder can tell the masked UUIDs apart from random ones or
+// unmask them.  The version and variant bits stay in place, so masked UUIDs
+// are valid Version 4 UUIDs: the other 122 bits are permuted by a 10 round
+// Feistel network over two 61 bit halves with AES as its round function.
+//
+// A Cipher is safe for concurrent use.
+type Cipher struct {
+	block   cipher.Block
+	version Version
+}
+
+// NewCipher returns a Cipher masking UUIDs of version with the AES key key,
+// which must be 16, 24 or 32 bytes long.
+func NewCipher(key []byte, version Version) (*Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{block: block, version: version}, nil
+}
+
+// Mask returns the Version 4 UUID that uuid is masked as.  An error is
+// returned if uuid is not an RFC 9562 UUID of the version of c.
+func (c *Cipher) Mask(uuid UUID) (UUID, error) {
+	if uuid.Version() != c.version || uuid.Variant() != RFC4122 {
+		return Nil, fmt.Errorf("cannot mask %s: not a version %d UUID", uuid, c.version)
+	}
+	l, r := maskSplit(uuid)
+	for i := 0; i < maskRounds; i++ {
+		l, r = r, l^c.round(i, r)
+	}
+	return maskJoin(l, r, 4), nil
+}
+
+// Unmask returns the UUID masked as uuid by Mask.  An error is returned if
+// uuid is not an RFC 9562 Version 4 UUID.
+func (c *Cipher) Unmask(uuid UUID) (UUID, error) {
+	if uuid.Version() != 4 || uuid.Variant() != RFC4122 {
+		return Nil, fmt.Errorf("cannot unmask %s: not a version 4 UUID", uuid)
+	}
+	l, r := maskSplit(uuid)
+	for i := maskRounds - 1; i >= 0; i-- {
+		l, r = r^c.round(i, l), l
+	}
+	return maskJoin(l, r, c.version), nil
+}
+
+// round returns the 61 bit output of the round function of round i for x.
+func (c *Cipher) round(i int, x uint64) uint64 {
+	var b [aes.BlockSize]byte
+	b[0] = byte(i)
+	binary.BigEndian.PutUint64(b[8:], x)
+	c.block.Encrypt(b[:], b[:])
+	return binary.BigEndian.Uint64(b[:]) >> 3
+}
+
+// maskSplit returns the 122 bits of uuid other than its version and variant
+// as two 61 bit halves.
+func maskSplit(uuid UUID) (l, r uint64) {
+	hi, lo := uuid.Uint64Pair()
+	hi = hi>>16<<12 | hi&0xfff // 60 bits
+	lo &= 1<<62 - 1
+	return hi<<1 | lo>>61, lo & (1<<61 - 1)
+}
+
+// maskJoin returns the UUID of version with the bits split by maskSplit.
+func maskJoin(l, r uint64, version Version) UUID {
+	hi := l >> 1
+	hi = hi>>12<<16 | uint64(version&0xf)<<12 | hi&0xfff
+	lo := 1<<63 | (l&1)<<61 | r // Variant is 10
+	return FromUint64Pair(hi, lo)
+}
+
+var maskCipher atomic.Value // *Cipher set by SetMaskCipher
+
+// SetMaskCipher sets the Cipher used by Masked.
+func SetMaskCipher(c *Cipher) {
+	maskCipher.Store(c)
+}
+
+func getMaskCipher() *Cipher {
+	c, _ := maskCipher.Load().(*Cipher)
+	return c
+}
+
+// maskedInvalid is the string form of a Masked that cannot be masked.
+const maskedInvalid = "uuid.Masked(INVALID)"
+
+// A Masked is a UUID, such as a time-ordered key used internally, that is
+// masked by the Cipher set with SetMaskCipher whenever it is formatted or
+// marshaled as text, and unmasked when it is unmarshaled.  Storing a Masked
+// in a struct that is encoded as JSON exposes only the masked form.  The zero
+// Masked, the Nil UUID, is not masked.
+type Masked UUID
+
+// String returns the string form of the masked form of m.  If m cannot be
+// masked, because SetMaskCipher has not been called or m is not of the
+// version of the Cipher, String returns "uuid.Masked(INVALID)" rather than
+// expose m.
+func (m Masked) String() string {
+	uuid, err := m.mask()
+	if err != nil {
+		return maskedInvalid
+	}
+	return uuid.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m Masked) MarshalText() ([]byte, error) {
+	uuid, err := m.mask()
+	if err != nil {
+		return nil, err
+	}
+	return uuid.MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *Masked) UnmarshalText(data []byte) error {
+	uuid, err := ParseBytes(data)
+	if err != nil {
+		return err
+	}
+	if uuid == Nil {
+		*m = Masked(Nil)
+		return nil
+	}
+	c := getMaskCipher()
+	if c == nil {
+		return ErrNoMaskCipher
+	}
+	if uuid, err = c.Unmask(uuid); err != nil {
+		return err
+	}
+	*m = Masked(uuid)
+	return nil
+}
+
+// mask returns the masked form of m, which is Nil for Nil.
+func (m Masked) mask() (UUID, error) {
+	if UUID(m) == Nil {
+		return Nil, nil
+	}
+	c := getMaskCipher()
+	if c == nil {
+		return Nil, ErrNoMaskCipher
+	}
+	return c.Mask(UUID(m))
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCipher(t *testing.T) {
+	c, err := NewCipher([]byte("0123456789abcdef"), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[UUID]bool{}
+	for i := 0; i < 1000; i++ {
+		uuid := Must(NewV7())
+		masked, err := c.Mask(uuid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if masked.Version() != 4 || masked.Variant() != RFC4122 {
+			t.Fatalf("%s: version %s, variant %s", masked, masked.Version(), masked.Variant())
+		}
+		if seen[masked] {
+			t.Fatalf("%s masked twice", masked)
+		}
+		seen[masked] = true
+		if masked.String()[:8] == uuid.String()[:8] {
+			t.Errorf("%s masked as %s keeps its timestamp", uuid, masked)
+		}
+		back, err := c.Unmask(masked)
+		if err != nil || back != uuid {
+			t.Fatalf("Unmask(%s) = %s, %v, want %s", masked, back, err, uuid)
+		}
+	}
+
+	// The permutation covers all 122 bits.
+	for _, uuid := range []UUID{
+		MustParse("00000000-0000-7000-8000-000000000000"),
+		MustParse("ffffffff-ffff-7fff-bfff-ffffffffffff"),
+	} {
+		masked, _ := c.Mask(uuid)
+		if back, _ := c.Unmask(masked); back != uuid {
+			t.Errorf("Unmask(Mask(%s)) = %s", uuid, back)
+		}
+	}
+
+	other, _ := NewCipher([]byte("fedcba9876543210"), 7)
+	uuid := Must(NewV7())
+	if a, _ := c.Mask(uuid); a == Must(other.Mask(uuid)) {
+		t.Error("different keys gave the same mask")
+	}
+	if _, err := c.Mask(New()); err == nil {
+		t.Error("masked a version 4 UUID with a version 7 Cipher")
+	}
+	if _, err := c.Unmask(uuid); err == nil {
+		t.Error("unmasked a version 7 UUID")
+	}
+	if _, err := NewCipher([]byte("short"), 7); err == nil {
+		t.Error("NewCipher accepted a short key")
+	}
+}
+
+func TestMasked(t *testing.T) {
+	type record struct {
+		ID Masked `json:"id"`
+	}
+	defer SetMaskCipher(nil)
+	SetMaskCipher(nil)
+	if _, err := json.Marshal(record{ID: Masked(Must(NewV7()))}); !errors.Is(err, ErrNoMaskCipher) {
+		t.Errorf("got error %v, want %v", err, ErrNoMaskCipher)
+	}
+	if s := fmt.Sprintf("%v", record{ID: Masked(Must(NewV7()))}); s != "{uuid.Masked(INVALID)}" {
+		t.Errorf("got %s without a cipher", s)
+	}
+
+	c, _ := NewCipher([]byte("0123456789abcdef0123456789abcdef"), 7)
+	SetMaskCipher(c)
+	id := Must(NewV7())
+	data, err := json.Marshal(record{ID: Masked(id)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	masked, _ := c.Mask(id)
+	if want := `{"id":"` + masked.String() + `"}`; string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+	if s := Masked(id).String(); s != masked.String() {
+		t.Errorf("String got %s, want %s", s, masked)
+	}
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil || UUID(r.ID) != id {
+		t.Errorf("got %s %v, want %s", UUID(r.ID), err, id)
+	}
+	if s := Masked(Must(NewRandom())).String(); s != "uuid.Masked(INVALID)" {
+		t.Errorf("String of a version 4 UUID got %s", s)
+	}
+}
+
+func TestMaskedZero(t *testing.T) {
+	type record struct {
+		ID Masked `json:"id"`
+	}
+	defer SetMaskCipher(nil)
+	for _, c := range []*Cipher{nil, mustCipher(t)} {
+		SetMaskCipher(c)
+		var zero record
+		if s := fmt.Sprintf("%v", zero); s != "{"+Nil.String()+"}" {
+			t.Errorf("got %s", s)
+		}
+		data, err := json.Marshal(zero)
+		if want := `{"id":"` + Nil.String() + `"}`; err != nil || string(data) != want {
+			t.Errorf("got %s %v, want %s", data, err, want)
+		}
+		r := record{ID: Masked(Must(NewV7()))}
+		if err := json.Unmarshal(data, &r); err != nil || r != zero {
+			t.Errorf("got %s %v, want the zero Masked", UUID(r.ID), err)
+		}
+	}
+}
+
+func mustCipher(t *testing.T) *Cipher {
+	c, err := NewCipher([]byte("0123456789abcdef0123456789abcdef"), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding"
+	"hash"
+	"io"
+	"sync"
+)
+
+// A Namer generates name-based UUIDs in one name space, as NewHash does, for
+// many names.  The hash state after the name space is computed once and
+// restored for every name, and hashes are reused, so generating a UUID does
+// not allocate.  A Namer is safe for concurrent use.
+type Namer struct {
+	space   UUID
+	version int
+	newHash func() hash.Hash
+	state   []byte // state of a hash after space, nil if not marshalable
+	pool    sync.Pool
+}
+
+// namerHash is a hash of a Namer with buffers for its sum and for names
+// passed as strings.
+type namerHash struct {
+	h   hash.Hash
+	sum []byte
+	buf [256]byte
+}
+
+// NewNamer returns a Namer for the name space space whose UUIDs are formed,
+// as by NewHash, from the first 16 bytes of the hash returned by newHash,
+// and have the lower 4 bits of version as their version.
+func NewNamer(newHash func() hash.Hash, space UUID, version int) *Namer {
+	n := &Namer{space: space, version: version, newHash: newHash}
+	h := newHash()
+	h.Write(space[:]) //nolint:errcheck
+	if m, ok := h.(encoding.BinaryMarshaler); ok {
+		if _, ok := h.(encoding.BinaryUnmarshaler); ok {
+			n.state, _ = m.MarshalBinary()
+		}
+	}
+	n.pool.Put(&namerHash{h: h, sum: make([]byte, 0, h.Size())})
+	return n
+}
+
+// NewMD5Namer returns a Namer of MD5 (Version 3) UUIDs in space.
+func NewMD5Namer(space UUID) *Namer {
+	return NewNamer(md5.New, space, 3)
+}
+
+// NewSHA1Namer returns a Namer of SHA1 (Version 5) UUIDs in space.
+func NewSHA1Namer(space UUID) *Namer {
+	return NewNamer(sha1.New, space, 5)
+}
+
+// Space returns the name space of n.
+func (n *Namer) Space() UUID {
+	return n.space
+}
+
+// New returns the UUID for the name data.  It is the same as
+//
+//	NewHash(h, space, data, version)
+//
+// with the arguments of the NewNamer call that returned n.
+func (n *Namer) New(data []byte) UUID {
+	nh := n.get()
+	nh.h.Write(data) //nolint:errcheck
+	return n.put(nh)
+}
+
+// NewString is like New but takes the name as a string.
+func (n *Namer) NewString(name string) UUID {
+	nh := n.get()
+	// Copy the name through buf, as converting it to a []byte allocates.
+	for name != "" {
+		c := copy(nh.buf[:], name)
+		nh.h.Write(nh.buf[:c]) //nolint:errcheck
+		name = name[c:]
+	}
+	return n.put(nh)
+}
+
+// NewFromReader is like New but reads the name from r until EOF, so a large
+// name need not be held in memory.  An error reading r is returned with Nil.
+func (n *Namer) NewFromReader(r io.Reader) (UUID, error) {
+	nh := n.get()
+	if _, err := io.Copy(nh.h, r); err != nil {
+		n.pool.Put(nh)
+		return Nil, err
+	}
+	return n.put(nh), nil
+}
+
+// get returns a hash that has been written the name space of n.
+func (n *Namer) get() *namerHash {
+	nh, _ := n.pool.Get().(*namerHash)
+	if nh == nil {
+		h := n.newHash()
+		nh = &namerHash{h: h, sum: make([]byte, 0, h.Size())}
+	}
+	if n.state != nil {
+		if err := nh.h.(encoding.BinaryUnmarshaler).UnmarshalBinary(n.state); err == nil {
+			return nh
+		}
+	}
+	nh.h.Reset()
+	nh.h.Write(n.space[:]) //nolint:errcheck
+	return nh
+}
+
+// put returns the UUID formed from the sum of nh and puts nh back in the pool.
+func (n *Namer) put(nh *namerHash) UUID {
+	nh.sum = nh.h.Sum(nh.sum[:0])
+	var uuid UUID
+	copy(uuid[:], nh.sum)
+	uuid[6] = (uuid[6] & 0x0f) | uint8((n.version&0xf)<<4)
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // RFC 9562 variant
+	n.pool.Put(nh)
+	return uuid
+}
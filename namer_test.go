@@ -0,0 +1,115 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNamer(t *testing.T) {
+	md5n := NewMD5Namer(NameSpaceDNS)
+	sha1n := NewSHA1Namer(NameSpaceURL)
+	for _, name := range []string{"", "python.org", "https://example.com/" + strings.Repeat("x", 200)} {
+		if got, want := md5n.New([]byte(name)), NewMD5(NameSpaceDNS, []byte(name)); got != want {
+			t.Errorf("MD5 %q: got %s, want %s", name, got, want)
+		}
+		want := NewSHA1(NameSpaceURL, []byte(name))
+		if got := sha1n.New([]byte(name)); got != want {
+			t.Errorf("SHA1 %q: got %s, want %s", name, got, want)
+		}
+		if got := sha1n.NewString(name); got != want {
+			t.Errorf("NewString %q: got %s, want %s", name, got, want)
+		}
+		got, err := sha1n.NewFromReader(iotest.OneByteReader(strings.NewReader(name)))
+		if err != nil || got != want {
+			t.Errorf("NewFromReader %q: got %s %v, want %s", name, got, err, want)
+		}
+	}
+	if got := md5n.NewString("python.org").String(); got != "6fa459ea-ee8a-3ca4-894e-db77e160355e" {
+		t.Errorf("got %s", got)
+	}
+
+	// Hashes whose state cannot be saved have the name space written again.
+	opaque := func() hash.Hash { return struct{ hash.Hash }{sha256.New()} }
+	for _, newHash := range []func() hash.Hash{sha256.New, opaque} {
+		n := NewNamer(newHash, NameSpaceOID, 8)
+		if got, want := n.New([]byte("1.3.6.1")), NewHash(newHash(), NameSpaceOID, []byte("1.3.6.1"), 8); got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	}
+}
+
+func TestNamerReaderError(t *testing.T) {
+	n := NewSHA1Namer(NameSpaceDNS)
+	errRead := errors.New("read error")
+	if uuid, err := n.NewFromReader(iotest.ErrReader(errRead)); err != errRead || uuid != Nil {
+		t.Errorf("got %s %v, want %v", uuid, err, errRead)
+	}
+	// The failed read leaves nothing behind.
+	if got, want := n.NewString("a"), NewSHA1(NameSpaceDNS, []byte("a")); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestNamerConcurrent(t *testing.T) {
+	n := NewSHA1Namer(NameSpaceDNS)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				name := []byte(fmt.Sprintf("host%d-%d.example.com", g, i))
+				if got, want := n.New(name), NewSHA1(NameSpaceDNS, name); got != want {
+					t.Errorf("%s: got %s, want %s", name, got, want)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestNamerAllocs(t *testing.T) {
+	n := NewSHA1Namer(NameSpaceDNS)
+	long := strings.Repeat("a long name, ", 100)
+	if got, want := n.NewString(long), NewSHA1(NameSpaceDNS, []byte(long)); got != want {
+		t.Errorf("NewString of a long name got %s, want %s", got, want)
+	}
+	if raceEnabled {
+		t.Skip("allocations are not counted with the race detector")
+	}
+	name := []byte("www.example.com")
+	if allocs := testing.AllocsPerRun(100, func() { n.New(name) }); allocs != 0 {
+		t.Errorf("New made %v allocations, want 0", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { n.NewString(long) }); allocs != 0 {
+		t.Errorf("NewString made %v allocations, want 0", allocs)
+	}
+}
+
+func BenchmarkNamer(b *testing.B) {
+	n := NewSHA1Namer(NameSpaceDNS)
+	name := []byte("www.example.com")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		n.New(name)
+	}
+}
+
+func BenchmarkNewSHA1(b *testing.B) {
+	name := []byte("www.example.com")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewSHA1(NameSpaceDNS, name)
+	}
+}
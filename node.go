@@ -9,10 +9,11 @@ import (
 )
 
 var (
-	nodeMu sync.Mutex
-	ifname string  // name of interface being used
-	nodeID [6]byte // hardware for version 1 UUIDs
-	zeroID [6]byte // nodeID with only 0's
+	nodeMu       sync.Mutex
+	ifname       string         // name of interface being used
+	nodeID       [6]byte        // hardware for version 1 UUIDs
+	zeroID       [6]byte        // nodeID with only 0's
+	nodeProvider NodeIDProvider // set by SetNodeIDProvider, nil for the default
 )
 
 // NodeInterface returns the name of the interface from which the NodeID was
@@ -29,41 +30,74 @@ func NodeInterface() string {
 // Node ID will be generated.  If a named interface cannot be found then false
 // is returned.
 //
-// SetNodeInterface never fails when name is "".
+// When name is "", SetNodeInterface only fails if the random number generator
+// fails.
 func SetNodeInterface(name string) bool {
 	defer nodeMu.Unlock()
 	nodeMu.Lock()
-	return setNodeInterface(name)
+	ok, _ := setNodeInterface(name)
+	return ok
 }
 
-func setNodeInterface(name string) bool {
+func setNodeInterface(name string) (bool, error) {
 	iname, addr := getHardwareInterface(name) // null implementation for js
 	if iname != "" && addr != nil {
 		ifname = iname
 		copy(nodeID[:], addr)
-		return true
+		return true, nil
 	}
 
 	// We found no interfaces with a valid hardware address.  If name
 	// does not specify a specific interface generate a random Node ID
-	// (section 4.1.6)
+	// (section 6.10)
 	if name == "" {
+		id, _, err := RandomNode{}.NodeID()
+		if err != nil {
+			return false, err
+		}
 		ifname = "random"
-		randomBits(nodeID[:])
-		return true
+		copy(nodeID[:], id)
+		return true, nil
 	}
-	return false
+	return false, nil
 }
 
-// NodeID returns a slice of a copy of the current Node ID, setting the Node ID
-// if not already set.
-func NodeID() []byte {
+// initNodeID sets the Node ID from the NodeIDProvider set by
+// SetNodeIDProvider, or as SetNodeInterface("") does if there is none or it
+// fails.  An error is returned if a random Node ID is needed and the random
+// number generator fails.
+func initNodeID() error {
+	if nodeProvider != nil {
+		if id, name, err := nodeProvider.NodeID(); err == nil && len(id) >= 6 {
+			copy(nodeID[:], id)
+			ifname = name
+			return nil
+		}
+	}
+	_, err := setNodeInterface("")
+	return err
+}
+
+// getNodeID returns the current Node ID, setting the Node ID if not already
+// set.
+func getNodeID() ([6]byte, error) {
 	defer nodeMu.Unlock()
 	nodeMu.Lock()
 	if nodeID == zeroID {
-		setNodeInterface("")
+		if err := initNodeID(); err != nil {
+			return zeroID, err
+		}
+	}
+	return nodeID, nil
+}
+
+// NodeID returns a slice of a copy of the current Node ID, setting the Node ID
+// if not already set.  NodeID returns nil if the Node ID cannot be set.
+func NodeID() []byte {
+	nid, err := getNodeID()
+	if err != nil {
+		return nil
 	}
-	nid := nodeID
 	return nid[:]
 }
 
@@ -10,3 +10,7 @@ package uuid
 // This removes the "net" dependency, because it is not used in the browser.
 // Using the "net" library inflates the size of the transpiled JS code by 673k bytes.
 func getHardwareInterface(name string) (string, []byte) { return "", nil }
+
+// NodeID implements NodeIDProvider.  There are no interfaces to choose from
+// in the browser.
+func (p InterfaceNode) NodeID() ([]byte, string, error) { return nil, "", ErrNoNodeID }
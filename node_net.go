@@ -31,3 +31,56 @@ func getHardwareInterface(name string) (string, []byte) {
 	}
 	return "", nil
 }
+
+// NodeID implements NodeIDProvider.
+func (p InterfaceNode) NodeID() ([]byte, string, error) {
+	ifs, err := net.Interfaces()
+	if err != nil {
+		return nil, "", err
+	}
+	var best *net.Interface
+	for i := range ifs {
+		ifc := &ifs[i]
+		if len(ifc.HardwareAddr) < 6 || isZero(ifc.HardwareAddr) {
+			continue
+		}
+		if p.Name != "" {
+			if ifc.Name == p.Name {
+				return ifc.HardwareAddr, ifc.Name, nil
+			}
+			continue
+		}
+		if ifc.Flags&net.FlagUp == 0 || ifc.Flags&net.FlagLoopback != 0 || p.skipInterface(ifc.Name) {
+			continue
+		}
+		if best == nil || betterInterface(ifc, best) {
+			best = ifc
+		}
+	}
+	if best == nil {
+		return nil, "", ErrNoNodeID
+	}
+	return best.HardwareAddr, best.Name, nil
+}
+
+// betterInterface reports whether a is preferred over b as the source of a
+// Node ID: a universally administered address beats a locally administered
+// one, then the lower index wins.
+func betterInterface(a, b *net.Interface) bool {
+	aLocal := a.HardwareAddr[0]&0x02 != 0
+	bLocal := b.HardwareAddr[0]&0x02 != 0
+	if aLocal != bLocal {
+		return bLocal
+	}
+	return a.Index < b.Index
+}
+
+// isZero reports whether all bytes of b are 0.
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
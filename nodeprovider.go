@@ -0,0 +1,190 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrNoNodeID is returned by a NodeIDProvider that cannot find a Node ID.
+var ErrNoNodeID = errors.New("no usable node ID")
+
+// A NodeIDProvider supplies the Node ID used for Version 1 and 6 UUIDs.
+type NodeIDProvider interface {
+	// NodeID returns a Node ID of at least 6 bytes and the name reported
+	// by NodeInterface for it.
+	NodeID() (id []byte, name string, err error)
+}
+
+// SetNodeIDProvider sets the Node ID used for Version 1 and 6 UUIDs to the one
+// returned by p.  The error from p is returned, in which case the Node ID is
+// not changed.  p is asked again if the Node ID ever needs to be set
+// automatically.
+//
+// Calling SetNodeIDProvider with nil restores the default, which is the
+// behavior of SetNodeInterface(""), when the next UUID is generated.
+func SetNodeIDProvider(p NodeIDProvider) error {
+	defer nodeMu.Unlock()
+	nodeMu.Lock()
+	if p == nil {
+		nodeProvider = nil
+		nodeID = zeroID
+		return nil
+	}
+	id, name, err := p.NodeID()
+	if err != nil {
+		return err
+	}
+	if len(id) < 6 {
+		return fmt.Errorf("node ID too short (got %d bytes)", len(id))
+	}
+	nodeProvider = p
+	copy(nodeID[:], id)
+	ifname = name
+	return nil
+}
+
+// RandomNode provides a random Node ID with the multicast bit set, as RFC 9562
+// requires so that it cannot collide with the address of a network card
+// (section 6.10).  A new Node ID is returned by each call.  Its name is
+// "random".
+type RandomNode struct{}
+
+// NodeID implements NodeIDProvider.
+func (RandomNode) NodeID() ([]byte, string, error) {
+	var id [6]byte
+	if err := randomBits(id[:]); err != nil {
+		return nil, "", err
+	}
+	id[0] |= 0x01 // multicast bit
+	return id[:], "random", nil
+}
+
+// InterfaceNode provides the hardware address of a network interface.  If
+// Name is set only that interface is considered.  Otherwise interfaces that
+// are down, loopback, or named like a virtual interface (such as docker0,
+// veth*, br-* or virbr*) are skipped, as are interfaces whose name starts with
+// any of SkipPrefixes.  Of the remaining interfaces, those with a universally
+// administered address are preferred, and then the one with the lowest index,
+// so the same interface is chosen every time.  The name of the Node ID is the
+// name of the interface.
+//
+// InterfaceNode fails with ErrNoNodeID if no interface qualifies.
+type InterfaceNode struct {
+	Name         string
+	SkipPrefixes []string
+}
+
+// virtualPrefixes are the name prefixes of common virtual interfaces, whose
+// addresses are often regenerated when a container or VM starts.
+var virtualPrefixes = []string{
+	"br-", "cali", "cilium", "cni", "docker", "flannel", "kube", "lxc",
+	"lxd", "podman", "tap", "tun", "veth", "virbr", "vmnet", "vboxnet",
+	"vnet", "weave", "wg", "zt",
+}
+
+// skipInterface reports whether the interface called name is to be skipped
+// as virtual.
+func (p InterfaceNode) skipInterface(name string) bool {
+	for _, prefix := range virtualPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	for _, prefix := range p.SkipPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// HashedNode provides a Node ID derived from the Node ID of Source, by default
+// InterfaceNode{}, so the hardware address is not revealed in UUIDs.  The Node
+// ID is the first 6 bytes of the SHA-256 hash of Salt followed by the Node ID
+// of Source, with the multicast bit set.  Its name is "hash".
+type HashedNode struct {
+	Salt   []byte
+	Source NodeIDProvider
+}
+
+// NodeID implements NodeIDProvider.
+func (p HashedNode) NodeID() ([]byte, string, error) {
+	src := p.Source
+	if src == nil {
+		src = InterfaceNode{}
+	}
+	id, _, err := src.NodeID()
+	if err != nil {
+		return nil, "", err
+	}
+	h := sha256.New()
+	h.Write(p.Salt) //nolint:errcheck
+	h.Write(id)     //nolint:errcheck
+	sum := h.Sum(nil)
+	sum[0] |= 0x01 // multicast bit
+	return sum[:6], "hash", nil
+}
+
+// EnvNode provides the Node ID held in the environment variable Var, written
+// as 12 hexadecimal digits optionally separated by ':', '-' or '.', such as
+// 01:23:45:67:89:ab.  Its name is "env".  EnvNode fails with ErrNoNodeID if
+// Var is not set.
+type EnvNode struct {
+	Var string
+}
+
+// NodeID implements NodeIDProvider.
+func (p EnvNode) NodeID() ([]byte, string, error) {
+	s, ok := os.LookupEnv(p.Var)
+	if !ok {
+		return nil, "", ErrNoNodeID
+	}
+	id, err := parseNodeID(s)
+	if err != nil {
+		return nil, "", fmt.Errorf("$%s: %v", p.Var, err)
+	}
+	return id, "env", nil
+}
+
+// FileNode provides the Node ID held in the file at Path, in the form accepted
+// by EnvNode.  Surrounding white space is ignored.  Its name is "file".
+type FileNode struct {
+	Path string
+}
+
+// NodeID implements NodeIDProvider.
+func (p FileNode) NodeID() ([]byte, string, error) {
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, "", err
+	}
+	id, err := parseNodeID(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %v", p.Path, err)
+	}
+	return id, "file", nil
+}
+
+// parseNodeID decodes a Node ID written as 12 hexadecimal digits optionally
+// separated by ':', '-' or '.'.
+func parseNodeID(s string) ([]byte, error) {
+	x := strings.NewReplacer(":", "", "-", "", ".", "").Replace(s)
+	if len(x) != 12 {
+		return nil, fmt.Errorf("invalid node ID %q", s)
+	}
+	id := make([]byte, 6)
+	for i := range id {
+		var ok bool
+		if id[i], ok = xtob(x[i*2], x[i*2+1]); !ok {
+			return nil, fmt.Errorf("invalid node ID %q", s)
+		}
+	}
+	return id, nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+type fixedNode []byte
+
+func (p fixedNode) NodeID() ([]byte, string, error) { return p, "fixed", nil }
+
+var errNodeID = errors.New("no node ID here")
+
+// errNode is a NodeIDProvider that always fails.
+type errNode struct{}
+
+func (errNode) NodeID() ([]byte, string, error) { return nil, "", errNodeID }
+
+// flakyNode is a NodeIDProvider that fails after its first call.
+type flakyNode struct{ calls *int }
+
+func (p flakyNode) NodeID() ([]byte, string, error) {
+	if *p.calls++; *p.calls > 1 {
+		return nil, "", errNodeID
+	}
+	return []byte{2, 4, 6, 8, 10, 12}, "flaky", nil
+}
+
+func TestNodeIDFallback(t *testing.T) {
+	defer SetNodeIDProvider(nil)
+
+	// When the provider fails later on, the Node ID falls back to the
+	// default of SetNodeInterface("").
+	var calls int
+	if err := SetNodeIDProvider(flakyNode{&calls}); err != nil {
+		t.Fatal(err)
+	}
+	nodeMu.Lock()
+	if ok, err := setNodeInterface(""); !ok || err != nil {
+		t.Fatalf("setNodeInterface failed: %v", err)
+	}
+	wantName, wantID := ifname, nodeID
+	nodeID = zeroID
+	nodeMu.Unlock()
+	id := NodeID()
+	if wantName == "random" {
+		copy(wantID[:], id) // a new random Node ID
+	}
+	if calls != 2 || !bytes.Equal(id, wantID[:]) || NodeInterface() != wantName {
+		t.Errorf("got %x %q after %d calls, want %x %q after 2", id, NodeInterface(), calls, wantID, wantName)
+	}
+
+	// HashedNode does not fall back from a failing Source.
+	if _, _, err := (HashedNode{Source: errNode{}}).NodeID(); err != errNodeID {
+		t.Errorf("HashedNode got error %v, want %v", err, errNodeID)
+	}
+	if err := SetNodeIDProvider(HashedNode{Source: errNode{}}); err != errNodeID {
+		t.Errorf("SetNodeIDProvider got error %v, want %v", err, errNodeID)
+	}
+	if _, name, err := (HashedNode{Source: fixedNode{1, 2, 3, 4, 5, 6}}).NodeID(); name != "hash" || err != nil {
+		t.Errorf("HashedNode got %q %v", name, err)
+	}
+}
+
+func TestSetNodeIDProvider(t *testing.T) {
+	defer SetNodeIDProvider(nil)
+
+	nid := []byte{2, 4, 6, 8, 10, 12}
+	if err := SetNodeIDProvider(fixedNode(nid)); err != nil {
+		t.Fatalf("SetNodeIDProvider: %v", err)
+	}
+	if ni := NodeInterface(); ni != "fixed" {
+		t.Errorf("NodeInterface got %q, want %q", ni, "fixed")
+	}
+	for _, uuid := range []UUID{Must(NewUUID()), Must(NewV6())} {
+		if !bytes.Equal(uuid.NodeID(), nid) {
+			t.Errorf("%s: got node %x, want %x", uuid, uuid.NodeID(), nid)
+		}
+	}
+
+	if err := SetNodeIDProvider(fixedNode{1, 2}); err == nil {
+		t.Error("SetNodeIDProvider accepted a short node ID")
+	}
+	if err := SetNodeIDProvider(EnvNode{"UUID_TEST_UNSET_NODE_ID"}); err != ErrNoNodeID {
+		t.Errorf("got error %v, want %v", err, ErrNoNodeID)
+	}
+	if !bytes.Equal(NodeID(), nid) {
+		t.Errorf("failed SetNodeIDProvider changed the node ID to %x", NodeID())
+	}
+}
+
+func TestRandomNode(t *testing.T) {
+	id, name, err := RandomNode{}.NodeID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "random" || len(id) != 6 {
+		t.Errorf("got %x %q", id, name)
+	}
+	if id[0]&0x01 == 0 {
+		t.Errorf("random node ID %x does not have the multicast bit set", id)
+	}
+
+	// The default falls back to the same when there are no interfaces.
+	defer nodeMu.Unlock()
+	nodeMu.Lock()
+	ok, err := setNodeInterface("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok && ifname == "random" && nodeID[0]&0x01 == 0 {
+		t.Errorf("random node ID %x does not have the multicast bit set", nodeID)
+	}
+}
+
+func TestInterfaceNode(t *testing.T) {
+	id, name, err := InterfaceNode{}.NodeID()
+	if errors.Is(err, ErrNoNodeID) || runtime.GOARCH == "js" {
+		t.Skip("no usable interface")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(id) < 6 {
+		t.Errorf("%s: got short node ID %x", name, id)
+	}
+	id2, name2, err := InterfaceNode{Name: name}.NodeID()
+	if err != nil || name2 != name || !bytes.Equal(id, id2) {
+		t.Errorf("InterfaceNode{%q} got %x %q %v, want %x", name, id2, name2, err, id)
+	}
+	// Another interface may qualify, just make sure it is not name.
+	if _, n, _ := (InterfaceNode{SkipPrefixes: []string{name}}).NodeID(); n == name {
+		t.Errorf("SkipPrefixes did not skip %q", name)
+	}
+}
+
+func TestHashedNode(t *testing.T) {
+	src := fixedNode{1, 2, 3, 4, 5, 6}
+	id1, name, err := HashedNode{Salt: []byte("a"), Source: src}.NodeID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "hash" || len(id1) != 6 || id1[0]&0x01 == 0 {
+		t.Errorf("got %x %q", id1, name)
+	}
+	id2, _, _ := HashedNode{Salt: []byte("a"), Source: src}.NodeID()
+	id3, _, _ := HashedNode{Salt: []byte("b"), Source: src}.NodeID()
+	if !bytes.Equal(id1, id2) {
+		t.Errorf("same salt gave %x and %x", id1, id2)
+	}
+	if bytes.Equal(id1, id3) || bytes.Equal(id1, src) {
+		t.Errorf("different salt gave %x for both", id1)
+	}
+}
+
+func TestEnvAndFileNode(t *testing.T) {
+	want := []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab}
+	for _, s := range []string{"01:23:45:67:89:ab", "01-23-45-67-89-AB", "0123.4567.89ab", "0123456789ab"} {
+		t.Setenv("UUID_TEST_NODE_ID", s)
+		id, name, err := EnvNode{"UUID_TEST_NODE_ID"}.NodeID()
+		if err != nil || name != "env" || !bytes.Equal(id, want) {
+			t.Errorf("EnvNode %q got %x %q %v, want %x", s, id, name, err, want)
+		}
+	}
+	t.Setenv("UUID_TEST_NODE_ID", "01:23:45")
+	if _, _, err := (EnvNode{"UUID_TEST_NODE_ID"}).NodeID(); err == nil {
+		t.Error("EnvNode accepted a short node ID")
+	}
+
+	path := filepath.Join(t.TempDir(), "node")
+	if err := os.WriteFile(path, []byte("01:23:45:67:89:ab\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	id, name, err := FileNode{path}.NodeID()
+	if err != nil || name != "file" || !bytes.Equal(id, want) {
+		t.Errorf("FileNode got %x %q %v, want %x", id, name, err, want)
+	}
+	if _, _, err := (FileNode{path + ".missing"}).NodeID(); err == nil {
+		t.Error("FileNode succeeded on a missing file")
+	}
+}
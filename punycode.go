@@ -0,0 +1,99 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import "strings"
+
+// Parameters of Punycode, RFC 3492 section 5.
+const (
+	punyBase        = 36
+	punyTMin        = 1
+	punyTMax        = 26
+	punySkew        = 38
+	punyDamp        = 700
+	punyInitialBias = 72
+	punyInitialN    = 128
+)
+
+// punycode returns the Punycode encoding of s, RFC 3492 section 6.3, without
+// the "xn--" prefix of IDNA.
+func punycode(s string) string {
+	input := []rune(s)
+	var out strings.Builder
+	for _, c := range input {
+		if c < 0x80 {
+			out.WriteRune(c)
+		}
+	}
+	b := out.Len()
+	h := b
+	if b > 0 {
+		out.WriteByte('-')
+	}
+	n, delta, bias := rune(punyInitialN), 0, punyInitialBias
+	for h < len(input) {
+		m := rune(0x7fffffff)
+		for _, c := range input {
+			if c >= n && c < m {
+				m = c
+			}
+		}
+		delta += int(m-n) * (h + 1)
+		n = m
+		for _, c := range input {
+			if c < n {
+				delta++
+			}
+			if c != n {
+				continue
+			}
+			q := delta
+			for k := punyBase; ; k += punyBase {
+				t := k - bias
+				if t < punyTMin {
+					t = punyTMin
+				} else if t > punyTMax {
+					t = punyTMax
+				}
+				if q < t {
+					break
+				}
+				out.WriteByte(punyDigit(t + (q-t)%(punyBase-t)))
+				q = (q - t) / (punyBase - t)
+			}
+			out.WriteByte(punyDigit(q))
+			bias = punyAdapt(delta, h+1, h == b)
+			delta = 0
+			h++
+		}
+		delta++
+		n++
+	}
+	return out.String()
+}
+
+// punyAdapt is the bias adaptation function of RFC 3492 section 6.1.
+func punyAdapt(delta, numPoints int, first bool) int {
+	if first {
+		delta /= punyDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+	k := 0
+	for delta > (punyBase-punyTMin)*punyTMax/2 {
+		delta /= punyBase - punyTMin
+		k += punyBase
+	}
+	return k + (punyBase-punyTMin+1)*delta/(delta+punySkew)
+}
+
+// punyDigit returns the lowercase basic code point of the digit d.
+func punyDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
@@ -0,0 +1,12 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build race
+// +build race
+
+package uuid
+
+// raceEnabled reports whether the race detector is on.  It makes sync.Pool
+// drop items at random, so allocations cannot be counted.
+const raceEnabled = true
@@ -0,0 +1,102 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"fmt"
+	"sync"
+)
+
+// The registered name spaces, starting with the well-known ones.
+var (
+	nsMu     sync.RWMutex
+	nsByName = map[string]UUID{
+		"dns":  NameSpaceDNS,
+		"url":  NameSpaceURL,
+		"oid":  NameSpaceOID,
+		"x500": NameSpaceX500,
+	}
+	nsByUUID = map[UUID]string{
+		NameSpaceDNS:  "dns",
+		NameSpaceURL:  "url",
+		NameSpaceOID:  "oid",
+		NameSpaceX500: "x500",
+	}
+)
+
+// A NameSpaceError is returned by RegisterNameSpace when the name or the UUID
+// of a name space is already registered for another.
+type NameSpaceError struct {
+	Name  string // the name being registered
+	Space UUID   // the UUID being registered
+
+	// The registered name space Name or Space collides with.
+	OtherName  string
+	OtherSpace UUID
+}
+
+func (e *NameSpaceError) Error() string {
+	return fmt.Sprintf("name space %q (%s) collides with registered name space %q (%s)", e.Name, e.Space, e.OtherName, e.OtherSpace)
+}
+
+// RegisterNameSpace registers space as the name space called name, so that
+// the packages of a program deriving UUIDs agree on their name spaces and do
+// not accidentally share one.  The well-known name spaces are registered as
+// "dns", "url", "oid" and "x500".  Registering the same name and UUID again
+// does nothing.  If name is already registered with another UUID, or space
+// with another name, a *NameSpaceError is returned.
+func RegisterNameSpace(name string, space UUID) error {
+	defer nsMu.Unlock()
+	nsMu.Lock()
+	if old, ok := nsByName[name]; ok {
+		if old == space {
+			return nil
+		}
+		return &NameSpaceError{Name: name, Space: space, OtherName: name, OtherSpace: old}
+	}
+	if old, ok := nsByUUID[space]; ok {
+		return &NameSpaceError{Name: name, Space: space, OtherName: old, OtherSpace: space}
+	}
+	nsByName[name] = space
+	nsByUUID[space] = name
+	return nil
+}
+
+// LookupNameSpace returns the name space registered as name.
+func LookupNameSpace(name string) (space UUID, ok bool) {
+	defer nsMu.RUnlock()
+	nsMu.RLock()
+	space, ok = nsByName[name]
+	return space, ok
+}
+
+// NameSpaceName returns the name the name space space is registered as.
+func NameSpaceName(space UUID) (name string, ok bool) {
+	defer nsMu.RUnlock()
+	nsMu.RLock()
+	name, ok = nsByUUID[space]
+	return name, ok
+}
+
+// Derive returns the SHA1 (Version 5) UUID of the path of names segments
+// below the name space space, such as an organization, a team in it and a
+// user in the team.  Each segment is hashed with the UUID derived from the
+// segments before it as its name space:
+//
+//	Derive(space, "org", "team", "user")
+//
+// is
+//
+//	NewSHA1(NewSHA1(NewSHA1(space, []byte("org")), []byte("team")), []byte("user"))
+//
+// so segments are never joined: "a/b" followed by "c" gives a different UUID
+// than "a" followed by "b/c".  Derive(Derive(space, a), b) is the same as
+// Derive(space, a, b), and Derive(space) is space.
+func Derive(space UUID, segments ...string) UUID {
+	for _, s := range segments {
+		space = NewSHA1(space, []byte(s))
+	}
+	return space
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegisterNameSpace(t *testing.T) {
+	if space, ok := LookupNameSpace("dns"); !ok || space != NameSpaceDNS {
+		t.Errorf("LookupNameSpace(dns) = %s, %v", space, ok)
+	}
+	if name, ok := NameSpaceName(NameSpaceX500); !ok || name != "x500" {
+		t.Errorf("NameSpaceName(NameSpaceX500) = %q, %v", name, ok)
+	}
+
+	space := MustParse("8d5c4f7e-3e4a-4b7e-9a34-0f0e2a5c9b11")
+	if err := RegisterNameSpace("test/registry", space); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterNameSpace("test/registry", space); err != nil {
+		t.Errorf("registering again: %v", err)
+	}
+	if got, ok := LookupNameSpace("test/registry"); !ok || got != space {
+		t.Errorf("LookupNameSpace = %s, %v", got, ok)
+	}
+
+	var e *NameSpaceError
+	err := RegisterNameSpace("test/registry", New())
+	if !errors.As(err, &e) || e.OtherName != "test/registry" || e.OtherSpace != space {
+		t.Errorf("name collision got error %v", err)
+	}
+	err = RegisterNameSpace("test/other", NameSpaceURL)
+	if !errors.As(err, &e) || e.OtherName != "url" || e.OtherSpace != NameSpaceURL {
+		t.Errorf("UUID collision got error %v", err)
+	}
+	if _, ok := LookupNameSpace("test/other"); ok {
+		t.Error("failed registration was registered")
+	}
+}
+
+func TestDerive(t *testing.T) {
+	space, _ := LookupNameSpace("dns")
+	want := NewSHA1(NewSHA1(NewSHA1(space, []byte("org")), []byte("team")), []byte("user"))
+	if got := Derive(space, "org", "team", "user"); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if got := Derive(Derive(space, "org"), "team", "user"); got != want {
+		t.Errorf("stepwise got %s, want %s", got, want)
+	}
+	if Derive(space) != space {
+		t.Error("Derive without segments changed the name space")
+	}
+	if Derive(space, "a/b", "c") == Derive(space, "a", "b/c") {
+		t.Error("a/b + c collides with a + b/c")
+	}
+	if Derive(space, "ab", "") == Derive(space, "a", "b") || Derive(space, "", "x") == Derive(space, "x") {
+		t.Error("empty segments collide")
+	}
+}
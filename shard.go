@@ -0,0 +1,118 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// The functions in this file assign UUIDs to buckets and samples.  They are
+// deterministic, fast, and spread UUIDs evenly, but they are not resistant to
+// callers choosing UUIDs to land in a particular bucket.
+
+// ShardKey returns 64 uniformly distributed bits derived from uuid, suitable
+// as the key of a sharding or sampling scheme.  ShardKey only uses the bits of
+// uuid that are random where the version defines them:
+//
+//	Version 4: all bits but the version and variant
+//	Version 7: the 62 bits of rand_b
+//	Others:    a hash of all 128 bits
+//
+// Versions 1 and 6 carry a timestamp and node ID, and versions 3 and 5 are
+// already hashes, so these, like any other version, are hashed in full.
+func (uuid UUID) ShardKey() uint64 {
+	hi, lo := uuid.Uint64Pair()
+	if uuid.Variant() == RFC4122 {
+		switch uuid.Version() {
+		case 4:
+			// The version and variant bits of hi and lo do not
+			// overlap, so each is XORed with a random bit.
+			return mix64(hi ^ lo)
+		case 7:
+			return mix64(lo & 0x3fffffffffffffff)
+		}
+	}
+	return mix64(mix64(hi) + lo)
+}
+
+// mix64 is the finalizer of SplitMix64.  It is a bijection that spreads every
+// input bit over the whole result.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// sampleSalt separates the keys used by Sample from those used by the bucket
+// functions, so being sampled says nothing about the bucket of a UUID.
+const sampleSalt = 0x9e3779b97f4a7c15
+
+// Bucket returns the bucket, in [0, n), that uuid belongs to.  Every bucket
+// is equally likely.  Changing n reassigns most UUIDs; use JumpBucket or
+// RendezvousBucket if buckets are added or removed over time.  Bucket panics
+// if n < 1.
+func (uuid UUID) Bucket(n int) int {
+	if n < 1 {
+		panic("uuid: invalid bucket count")
+	}
+	hi, _ := bits.Mul64(uuid.ShardKey(), uint64(n))
+	return int(hi)
+}
+
+// JumpBucket returns the bucket, in [0, n), that uuid belongs to using the
+// jump consistent hash of Lamping and Veach.  Growing n to n+1 only moves the
+// expected 1/(n+1) of UUIDs, all into the new bucket n.  JumpBucket panics if
+// n < 1.
+func (uuid UUID) JumpBucket(n int) int {
+	if n < 1 {
+		panic("uuid: invalid bucket count")
+	}
+	key := uuid.ShardKey()
+	b, j := int64(-1), int64(0)
+	for j < int64(n) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
+// RendezvousBucket returns the index of the node in nodes that uuid belongs
+// to using rendezvous (highest random weight) hashing.  Removing a node only
+// moves the UUIDs that belonged to it, and nodes may be listed in any order.
+// RendezvousBucket returns -1 if nodes is empty.
+func (uuid UUID) RendezvousBucket(nodes []string) int {
+	key := uuid.ShardKey()
+	best, bestWeight := -1, uint64(0)
+	for i, node := range nodes {
+		h := fnv.New64a()
+		h.Write([]byte(node)) //nolint:errcheck
+		w := mix64(key ^ h.Sum64())
+		if best < 0 || w > bestWeight {
+			best, bestWeight = i, w
+		}
+	}
+	return best
+}
+
+// Sample reports whether uuid is in a deterministic sample of the given
+// rate, the fraction of all UUIDs to include.  A rate of 0 or less samples
+// nothing and a rate of 1 or more samples everything.  The sample for a lower
+// rate is a subset of the sample for a higher rate, and is independent of the
+// buckets uuid belongs to.
+func (uuid UUID) Sample(rate float64) bool {
+	switch {
+	case rate <= 0 || math.IsNaN(rate):
+		return false
+	case rate >= 1:
+		return true
+	}
+	return mix64(uuid.ShardKey()^sampleSalt) < uint64(rate*(1<<64))
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"testing"
+)
+
+func TestShardKey(t *testing.T) {
+	// Version 7 UUIDs that differ only in their timestamp share a key.
+	a := MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	b := MustParse("01890a5d-ffff-7123-bcce-b302099a8057")
+	if a.ShardKey() != b.ShardKey() {
+		t.Errorf("v7 keys differ: %x and %x", a.ShardKey(), b.ShardKey())
+	}
+	// Version 1 UUIDs hash all of their bits.
+	c := MustParse("7d444840-9dc0-11d1-b245-5ffdce74fad2")
+	d := MustParse("7d444841-9dc0-11d1-b245-5ffdce74fad2")
+	if c.ShardKey() == d.ShardKey() {
+		t.Errorf("v1 keys are both %x", c.ShardKey())
+	}
+}
+
+func TestBucket(t *testing.T) {
+	const n, count = 10, 20000
+	for _, gen := range []struct {
+		name string
+		new  func() (UUID, error)
+	}{
+		{"v1", NewUUID},
+		{"v4", NewRandom},
+		{"v7", NewV7},
+	} {
+		var buckets, jumps [n]int
+		for i := 0; i < count; i++ {
+			uuid := Must(gen.new())
+			buckets[uuid.Bucket(n)]++
+			jumps[uuid.JumpBucket(n)]++
+		}
+		for i := 0; i < n; i++ {
+			// Expect 2000 per bucket, allow a wide margin.
+			if buckets[i] < 1600 || buckets[i] > 2400 {
+				t.Errorf("%s: Bucket %d holds %d of %d", gen.name, i, buckets[i], count)
+			}
+			if jumps[i] < 1600 || jumps[i] > 2400 {
+				t.Errorf("%s: JumpBucket %d holds %d of %d", gen.name, i, jumps[i], count)
+			}
+		}
+	}
+}
+
+func TestJumpBucketConsistent(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		uuid := New()
+		prev := uuid.JumpBucket(10)
+		if next := uuid.JumpBucket(11); next != prev && next != 10 {
+			t.Fatalf("%s moved from bucket %d to %d", uuid, prev, next)
+		}
+	}
+}
+
+func TestRendezvousBucket(t *testing.T) {
+	nodes := []string{"a", "b", "c", "d"}
+	if got := New().RendezvousBucket(nil); got != -1 {
+		t.Errorf("RendezvousBucket(nil) got %d, want -1", got)
+	}
+	for i := 0; i < 1000; i++ {
+		uuid := New()
+		b := uuid.RendezvousBucket(nodes)
+		if b == 3 {
+			continue
+		}
+		if got := uuid.RendezvousBucket(nodes[:3]); got != b {
+			t.Fatalf("%s moved from node %d to %d after removing node 3", uuid, b, got)
+		}
+	}
+}
+
+func TestSample(t *testing.T) {
+	const count = 20000
+	sampled := 0
+	for i := 0; i < count; i++ {
+		uuid := New()
+		if uuid.Sample(0) {
+			t.Fatalf("%s sampled at rate 0", uuid)
+		}
+		if !uuid.Sample(1) {
+			t.Fatalf("%s not sampled at rate 1", uuid)
+		}
+		if uuid.Sample(0.1) {
+			sampled++
+			if !uuid.Sample(0.5) {
+				t.Fatalf("%s sampled at rate 0.1 but not 0.5", uuid)
+			}
+		}
+	}
+	if sampled < 1600 || sampled > 2400 {
+		t.Errorf("sampled %d of %d at rate 0.1", sampled, count)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+)
+
+// ErrSharedClockUnsupported is returned by EnableSharedClock on systems
+// without file locks.
+var ErrSharedClockUnsupported = errors.New("shared clock not supported on this system")
+
+// Offsets of the last times stored in a shared clock file, each a big-endian
+// int64.
+const (
+	sharedV1Offset = 0 // Time of the last Version 1 or 6 UUID
+	sharedV7Offset = 8 // milli<<12 + seq of the last Version 7 UUID
+	sharedSize     = 16
+)
+
+// sharedClock is the file set by EnableSharedClock, nil if none.  It is
+// protected by timeMu.
+var sharedClock *os.File
+
+// EnableSharedClock coordinates the time of Version 1, 6 and 7 UUIDs with the
+// other processes on this host that call EnableSharedClock with the same path.
+// The last time used by any of them is kept in the file at path, which is
+// created if needed, and access to it is serialized with a file lock.  Every
+// process then returns UUIDs whose time is strictly greater than that of any
+// UUID returned before it by any process, so Version 6 and 7 UUIDs sort in the
+// order they were generated across all processes.
+//
+// While the shared clock is enabled a time that was already used is advanced
+// past the last time used, by 100ns for Version 1 and 6 UUIDs and by one step
+// of the sequence for Version 7 UUIDs, rather than changing the clock
+// sequence.  NewV6WithTime does not use the shared clock.  Errors accessing the
+// file are returned by the generating functions.
+//
+// EnableSharedClock returns ErrSharedClockUnsupported on systems without file
+// locks, such as Windows and js.
+func EnableSharedClock(path string) error {
+	if !haveFileLock {
+		return ErrSharedClockUnsupported
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o666)
+	if err != nil {
+		return err
+	}
+	defer timeMu.Unlock()
+	timeMu.Lock()
+	if sharedClock != nil {
+		sharedClock.Close()
+	}
+	sharedClock = f
+	return nil
+}
+
+// DisableSharedClock stops using the file set by EnableSharedClock.
+func DisableSharedClock() error {
+	defer timeMu.Unlock()
+	timeMu.Lock()
+	if sharedClock == nil {
+		return nil
+	}
+	err := sharedClock.Close()
+	sharedClock = nil
+	return err
+}
+
+// advanceShared returns now, or the time following the last time stored at
+// off in the shared clock file if that is not before now, and stores the
+// result as the last time.  timeMu must be held.
+func advanceShared(off int64, now int64) (int64, error) {
+	if err := lockFile(sharedClock); err != nil {
+		return 0, err
+	}
+	defer unlockFile(sharedClock) //nolint:errcheck
+
+	var b [sharedSize]byte
+	// A new file is shorter than sharedSize, and reads as 0.
+	if _, err := sharedClock.ReadAt(b[:], 0); err != nil && err != io.EOF {
+		return 0, err
+	}
+	if last := int64(binary.BigEndian.Uint64(b[off:])); now <= last {
+		now = last + 1
+	}
+	binary.BigEndian.PutUint64(b[off:], uint64(now))
+	if _, err := sharedClock.WriteAt(b[off:off+8], off); err != nil {
+		return 0, err
+	}
+	return now, nil
+}
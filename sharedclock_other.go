@@ -0,0 +1,16 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package uuid
+
+import "os"
+
+// haveFileLock is false on systems where the shared clock is not supported.
+const haveFileLock = false
+
+func lockFile(f *os.File) error   { return ErrSharedClockUnsupported }
+func unlockFile(f *os.File) error { return ErrSharedClockUnsupported }
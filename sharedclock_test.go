@@ -0,0 +1,151 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSharedClock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clock")
+	if err := EnableSharedClock(path); err == ErrSharedClockUnsupported {
+		t.Skip(err)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	defer DisableSharedClock()
+	defer func(v1 uint64, v7 int64) {
+		// Undo moving our clock into the future.
+		timeMu.Lock()
+		lasttime, lastV7time = v1, v7
+		timeMu.Unlock()
+	}(lasttime, lastV7time)
+
+	// Pretend another process generated UUIDs an hour from now.
+	later := timeNow().Add(3600e9)
+	var b [sharedSize]byte
+	binary.BigEndian.PutUint64(b[sharedV1Offset:], uint64(later.UnixNano()/100+g1582ns100))
+	binary.BigEndian.PutUint64(b[sharedV7Offset:], uint64(later.UnixNano()/nanoPerMilli<<12))
+	if err := os.WriteFile(path, b[:], 0o666); err != nil {
+		t.Fatal(err)
+	}
+	v6 := Must(NewV6())
+	if sec, _ := v6.Time().UnixTime(); sec < later.Unix() {
+		t.Errorf("%s: got time %d, want at least %d", v6, sec, later.Unix())
+	}
+	v7 := Must(NewV7())
+	if sec, _ := v7.Time().UnixTime(); sec < later.Unix() {
+		t.Errorf("%s: got time %d, want at least %d", v7, sec, later.Unix())
+	}
+
+	if err := DisableSharedClock(); err != nil {
+		t.Fatal(err)
+	}
+	// Our own clock has moved on too.
+	if next := Must(NewV7()); Compare(next, v7) <= 0 {
+		t.Errorf("%s sorts before %s", next, v7)
+	}
+}
+
+// TestSharedClockPolicy checks that the shared clock takes precedence over the
+// clock policy.
+func TestSharedClockPolicy(t *testing.T) {
+	set, _ := fakeClock(t, policyStart)
+	if err := EnableSharedClock(filepath.Join(t.TempDir(), "clock")); err == ErrSharedClockUnsupported {
+		t.Skip(err)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	defer DisableSharedClock()
+	SetClockPolicy(ClockError, 0)
+
+	v7, v1 := Must(NewV7()), Must(NewUUID())
+	set(policyStart.Add(-time.Second))
+	if next, err := NewV7(); err != nil || Compare(next, v7) <= 0 {
+		t.Errorf("NewV7 got %s, %v, want a UUID after %s", next, err, v7)
+	}
+	if next, err := NewUUID(); err != nil || next.Time() <= v1.Time() {
+		t.Errorf("NewUUID got %s, %v, want a UUID after %s", next, err, v1)
+	}
+}
+
+// TestSharedClockProcesses generates UUIDs in several processes sharing a
+// clock and checks that no two have the same time.
+func TestSharedClockProcesses(t *testing.T) {
+	if path := os.Getenv("UUID_TEST_SHARED_CLOCK"); path != "" {
+		sharedClockHelper(path)
+		return
+	}
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	path := filepath.Join(t.TempDir(), "clock")
+	if err := EnableSharedClock(path); err == ErrSharedClockUnsupported {
+		t.Skip(err)
+	}
+	DisableSharedClock()
+
+	const procs = 4
+	var outs [procs]bytes.Buffer
+	var cmds [procs]*exec.Cmd
+	for i := range cmds {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestSharedClockProcesses$")
+		cmd.Env = append(os.Environ(), "UUID_TEST_SHARED_CLOCK="+path)
+		cmd.Stdout = &outs[i]
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+		cmds[i] = cmd
+	}
+	v1times := make(map[Time]bool)
+	v7times := make(map[string]bool)
+	for i, cmd := range cmds {
+		if err := cmd.Wait(); err != nil {
+			t.Fatalf("process %d: %v", i, err)
+		}
+		s := bufio.NewScanner(&outs[i])
+		for s.Scan() {
+			uuid, err := Parse(s.Text())
+			if err != nil {
+				continue // output of the test framework
+			}
+			switch uuid.Version() {
+			case 6:
+				if v1times[uuid.Time()] {
+					t.Errorf("%s: time used twice", uuid)
+				}
+				v1times[uuid.Time()] = true
+			case 7:
+				prefix := uuid.String()[:18]
+				if v7times[prefix] {
+					t.Errorf("%s: time and sequence used twice", uuid)
+				}
+				v7times[prefix] = true
+			}
+		}
+	}
+	if len(v1times) != procs*1000 || len(v7times) != procs*1000 {
+		t.Errorf("got %d and %d UUIDs, want %d", len(v1times), len(v7times), procs*1000)
+	}
+}
+
+func sharedClockHelper(path string) {
+	if err := EnableSharedClock(path); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for i := 0; i < 1000; i++ {
+		fmt.Println(Must(NewV6()))
+		fmt.Println(Must(NewV7()))
+	}
+}
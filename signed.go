@@ -0,0 +1,190 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultSignatureBits is the length of the signature of a Signer whose
+// signature length is 0.
+const DefaultSignatureBits = 40
+
+var (
+	// ErrInvalidSignature is returned by Signer.Verify for UUIDs that were
+	// not signed by one of its keys.
+	ErrInvalidSignature = errors.New("invalid UUID signature")
+
+	// ErrUnknownKey is returned by Signer.SetCurrentKey for a key ID
+	// that was not added.
+	ErrUnknownKey = errors.New("unknown signing key")
+)
+
+// A Signer issues version 8 UUIDs that carry a truncated HMAC-SHA-256 of their
+// other bits, so that UUIDs sent back by clients can be checked to have been
+// issued by the holder of the key without looking them up.  A signed UUID has
+// the layout of the version 8 UUIDs of this package, starting with the time it
+// was signed:
+//
+//	 0                   1                   2                   3
+//	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|                           unix_ts_ms                          |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|          unix_ts_ms           |  ver  |  tag  |    key ID     |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|var|          seq          |    rand    |      signature       |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|                           signature                           |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// ver is 8, tag is 5 and var is 10.  seq is the 12 bit sequence of NewV7, so
+// the UUIDs of a process are unique and sort in the order they were issued.
+// The signature is the last 32 to 48 bits, and rand the 50 minus that many
+// random bits between seq and the signature.  The signature is the start of
+// the HMAC, under the key with the key ID, of the UUID with its signature bits
+// zeroed.
+//
+// Only rand tells apart the UUIDs that processes sharing a key sign with the
+// same timestamp and seq: with 40 signature bits they collide with a chance of
+// 1 in 1024, and with 48 bits of 1 in 4.  Processes on one host avoid this by
+// calling EnableSharedClock, which makes the timestamp and seq unique among
+// them.  Processes on different hosts should sign with keys of different key
+// IDs, which keeps their UUIDs apart.
+//
+// A Signer can hold up to 256 keys, identified by the 8 bit key ID, to rotate
+// keys: new UUIDs are signed with the current key, and UUIDs signed with any
+// key held are verified.  A Signer is safe for concurrent use.
+type Signer struct {
+	bits int
+
+	mu      sync.RWMutex
+	keys    map[uint8][]byte
+	current uint8
+}
+
+// NewSigner returns a Signer signing with key, whose key ID is keyID.  The
+// signatures are bits bits long, from 32 to 48, or DefaultSignatureBits if
+// bits is 0.  Each bit of signature halves the chance of a forged UUID
+// passing Verify and takes one of the 50 bits shared with the random bits,
+// doubling the chance of a collision between processes sharing key; see
+// Signer.
+func NewSigner(bits int, keyID uint8, key []byte) (*Signer, error) {
+	if bits == 0 {
+		bits = DefaultSignatureBits
+	}
+	if bits < 32 || bits > 48 {
+		return nil, fmt.Errorf("invalid signature length %d, not from 32 to 48", bits)
+	}
+	if len(key) == 0 {
+		return nil, errors.New("empty signing key")
+	}
+	s := &Signer{bits: bits, keys: map[uint8][]byte{}, current: keyID}
+	s.keys[keyID] = append([]byte(nil), key...)
+	return s, nil
+}
+
+// AddKey adds the key with key ID id, replacing the key with that ID, if any.
+// UUIDs signed with it are verified from now on.
+func (s *Signer) AddKey(id uint8, key []byte) {
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	s.keys[id] = append([]byte(nil), key...)
+}
+
+// SetCurrentKey makes the key with key ID id, which must have been added,
+// the key new UUIDs are signed with.
+func (s *Signer) SetCurrentKey(id uint8) error {
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	if _, ok := s.keys[id]; !ok {
+		return ErrUnknownKey
+	}
+	s.current = id
+	return nil
+}
+
+// RemoveKey removes the key with key ID id, so that UUIDs signed with it no
+// longer pass Verify.  The current key cannot be removed.
+func (s *Signer) RemoveKey(id uint8) {
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	if id != s.current {
+		delete(s.keys, id)
+	}
+}
+
+// New returns a new signed UUID.  Uses the randomness pool if it was enabled
+// with EnableRandPool.  On error, New returns Nil and an error.
+func (s *Signer) New() (UUID, error) {
+	uuid, err := NewRandom()
+	if err != nil {
+		return Nil, err
+	}
+	milli, seq, err := getV7Time()
+	if err != nil {
+		return Nil, err
+	}
+	putMilli(&uuid, milli)
+	uuid[8] = byte(seq >> 6)
+	uuid[9] = byte(seq<<2) | uuid[9]&0x03
+	return s.Sign(uuid), nil
+}
+
+// Sign returns uuid signed with the current key: the version, tag, key ID,
+// variant and signature bits of uuid are set, and the others kept.
+func (s *Signer) Sign(uuid UUID) UUID {
+	s.mu.RLock()
+	id := s.current
+	key := s.keys[id]
+	s.mu.RUnlock()
+
+	uuid[6] = 0x80 | v8Signed // Version 8
+	uuid[7] = id
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant is 10
+	hi, lo := uuid.Uint64Pair()
+	lo = lo>>uint(s.bits)<<uint(s.bits) | s.signature(key, uuid)
+	return FromUint64Pair(hi, lo)
+}
+
+// Verify returns nil if uuid was signed with one of the keys of s, and
+// ErrInvalidSignature otherwise.  The signature is checked in constant time.
+func (s *Signer) Verify(uuid UUID) error {
+	if uuid.v8Tag() != v8Signed {
+		return ErrInvalidSignature
+	}
+	s.mu.RLock()
+	key, ok := s.keys[uuid[7]]
+	s.mu.RUnlock()
+	if !ok {
+		return ErrInvalidSignature
+	}
+	_, lo := uuid.Uint64Pair()
+	var got, want [8]byte
+	binary.BigEndian.PutUint64(got[:], lo&(1<<uint(s.bits)-1))
+	binary.BigEndian.PutUint64(want[:], s.signature(key, uuid))
+	if subtle.ConstantTimeCompare(got[:], want[:]) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// signature returns the signature of uuid under key.
+func (s *Signer) signature(key []byte, uuid UUID) uint64 {
+	hi, lo := uuid.Uint64Pair()
+	var msg [16]byte
+	binary.BigEndian.PutUint64(msg[:], hi)
+	binary.BigEndian.PutUint64(msg[8:], lo>>uint(s.bits)<<uint(s.bits))
+	mac := hmac.New(sha256.New, key)
+	mac.Write(msg[:]) //nolint:errcheck
+	var sum [sha256.Size]byte
+	return binary.BigEndian.Uint64(mac.Sum(sum[:0])) >> uint(64-s.bits)
+}
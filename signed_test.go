@@ -0,0 +1,98 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSigner(t *testing.T) {
+	s, err := NewSigner(0, 1, []byte("first key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var last UUID
+	for i := 0; i < 100; i++ {
+		uuid, err := s.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if uuid.Version() != 8 || uuid.Variant() != RFC4122 {
+			t.Fatalf("%s: version %s, variant %s", uuid, uuid.Version(), uuid.Variant())
+		}
+		if err := s.Verify(uuid); err != nil {
+			t.Fatalf("Verify(%s): %v", uuid, err)
+		}
+		if Compare(uuid, last) <= 0 {
+			t.Fatalf("%s sorts before %s", uuid, last)
+		}
+		last = uuid
+	}
+	if d := time.Since(time.Unix(last.Time().UnixTime())); d < 0 || d > time.Minute {
+		t.Errorf("%s: time %v off by %v", last, time.Unix(last.Time().UnixTime()), d)
+	}
+
+	// Flipping any bit other than the version and variant breaks it.
+	for bit := 0; bit < 128; bit++ {
+		if bit >= 48 && bit < 52 || bit == 64 || bit == 65 {
+			continue
+		}
+		forged := last
+		forged[bit/8] ^= 0x80 >> uint(bit%8)
+		if s.Verify(forged) == nil {
+			t.Errorf("flipping bit %d of %s passed Verify", bit, last)
+		}
+	}
+	if s.Verify(New()) != ErrInvalidSignature || s.Verify(Must(NewV7())) != ErrInvalidSignature {
+		t.Error("unsigned UUID passed Verify")
+	}
+
+	other, _ := NewSigner(0, 1, []byte("other key"))
+	if other.Verify(last) == nil {
+		t.Error("UUID signed with another key passed Verify")
+	}
+	if u := Must(NewV7()); s.Sign(u) != s.Sign(s.Sign(u)) || s.Verify(s.Sign(u)) != nil {
+		t.Error("Sign is not idempotent")
+	}
+}
+
+func TestSignerRotation(t *testing.T) {
+	s, _ := NewSigner(32, 1, []byte("first key"))
+	old := Must(s.New())
+	if err := s.SetCurrentKey(2); err != ErrUnknownKey {
+		t.Errorf("got error %v, want %v", err, ErrUnknownKey)
+	}
+	s.AddKey(2, []byte("second key"))
+	if err := s.SetCurrentKey(2); err != nil {
+		t.Fatal(err)
+	}
+	current := Must(s.New())
+	if current[7] != 2 {
+		t.Errorf("%s: key ID %d, want 2", current, current[7])
+	}
+	if s.Verify(old) != nil || s.Verify(current) != nil {
+		t.Error("Verify failed after rotation")
+	}
+	s.RemoveKey(1)
+	s.RemoveKey(2) // the current key stays
+	if s.Verify(old) == nil {
+		t.Error("UUID signed with a removed key passed Verify")
+	}
+	if s.Verify(current) != nil {
+		t.Error("current key was removed")
+	}
+}
+
+func TestNewSignerErrors(t *testing.T) {
+	for _, bits := range []int{-1, 31, 49, 64} {
+		if _, err := NewSigner(bits, 0, []byte("key")); err == nil {
+			t.Errorf("NewSigner accepted %d bits", bits)
+		}
+	}
+	if _, err := NewSigner(0, 0, nil); err == nil {
+		t.Error("NewSigner accepted an empty key")
+	}
+}
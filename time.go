@@ -6,6 +6,7 @@ package uuid
 
 import (
 	"encoding/binary"
+	"fmt"
 	"sync"
 	"time"
 )
@@ -22,23 +23,93 @@ const (
 	g1582ns100 = g1582 * 10000000 // 100s of a nanoseconds between epochs
 )
 
+// maxTime is the latest Time that fits in the 60 bits of Version 1 and 6 UUIDs,
+// in the year 5236.
+const maxTime = 1<<60 - 1
+
+// maxV7Milli is the latest Unix millisecond time that fits in the 48 bits of
+// Version 7 UUIDs, in the year 10889.
+const maxV7Milli = 1<<48 - 1
+
+// A TimeRangeError is returned for a time a UUID of Version cannot hold.  Use
+// TimeRange to find the times it can hold.
+type TimeRangeError struct {
+	Version Version
+	Time    time.Time
+}
+
+func (e TimeRangeError) Error() string {
+	return fmt.Sprintf("time %v out of range for version %d UUIDs", e.Time, e.Version)
+}
+
+func (e TimeRangeError) Is(target error) bool {
+	_, ok := target.(TimeRangeError)
+	return ok
+}
+
+// ErrTimeRange matches any TimeRangeError with errors.Is.
+var ErrTimeRange = TimeRangeError{}
+
 var (
-	timeMu   sync.Mutex
-	lasttime uint64 // last time we returned
-	clockSeq uint16 // clock sequence for this run
+	timeMu      sync.Mutex
+	lasttime    uint64 // last time we returned
+	lastCustom  uint64 // last custom time we returned
+	clockSeq    uint16 // clock sequence for this run
+	tickCounter bool   // set by EnableTickCounter
 
 	timeNow = time.Now // for testing
 )
 
 // UnixTime converts t the number of seconds and nanoseconds using the Unix
-// epoch of 1 Jan 1970.
+// epoch of 1 Jan 1970.  nsec is always in the range [0, 999999999], also for
+// times before 1970, as for time.Unix.
 func (t Time) UnixTime() (sec, nsec int64) {
-	sec = int64(t - g1582ns100)
-	nsec = (sec % 10000000) * 100
-	sec /= 10000000
+	d := int64(t - g1582ns100)
+	sec = d / 10000000
+	nsec = (d % 10000000) * 100
+	if nsec < 0 {
+		sec--
+		nsec += 1000000000
+	}
 	return sec, nsec
 }
 
+// timeOf returns t as a Time.  ok is false if t is before 15 Oct 1582 or
+// does not fit in the 60 bits of Version 1 and 6 UUIDs.
+func timeOf(t time.Time) (ts Time, ok bool) {
+	sec := t.Unix() + g1582
+	if sec < 0 || sec > maxTime/10000000 {
+		return 0, false
+	}
+	ts = Time(sec*10000000 + int64(t.Nanosecond()/100))
+	return ts, ts <= maxTime
+}
+
+// TimeRange returns the earliest and latest times that UUIDs of version can
+// hold.  Versions 1 and 6 hold times from 15 Oct 1582 to the year 5236 in
+// steps of 100ns.  Version 2 holds the same range, but its local domain ID
+// replaces the low 32 bits of the time, leaving steps of 2^32 × 100ns, about
+// 7 minutes.  Version 7, and the version 8 UUIDs returned by
+// FromSnowflake, FromObjectID, NewTimeHash and Signer.New, hold Unix times
+// from 1 Jan 1970 to the year 10889 in steps of a millisecond.  ok is false
+// for versions without a time.
+func TimeRange(version Version) (min, max time.Time, ok bool) {
+	switch version {
+	case 1, 6:
+		min = time.Unix(-g1582, 0).UTC()
+		max = time.Unix(Time(maxTime).UnixTime()).UTC()
+	case 2:
+		min = time.Unix(-g1582, 0).UTC()
+		max = time.Unix(Time(maxTime &^ (1<<32 - 1)).UnixTime()).UTC()
+	case 7, 8:
+		min = time.Unix(0, 0).UTC()
+		max = time.UnixMilli(maxV7Milli).UTC()
+	default:
+		return min, max, false
+	}
+	return min, max, true
+}
+
 // GetTime returns the current Time (100s of nanoseconds since 15 Oct 1582) and
 // clock sequence as well as adjusting the clock sequence as needed.  An error
 // is returned if the current time cannot be determined.
@@ -50,17 +121,59 @@ func GetTime() (Time, uint16, error) {
 
 func getTime(customTime *time.Time) (Time, uint16, error) {
 	var t time.Time
+	var back bool
 	if customTime == nil { // When not provided, use the current time
-		t = timeNow()
+		t, back = readClock()
 	} else {
 		t = *customTime
 	}
 
 	// If we don't have a clock sequence already, set one.
 	if clockSeq == 0 {
-		setClockSequence(-1)
+		if err := setClockSequence(-1); err != nil {
+			return 0, 0, err
+		}
+	}
+	// A custom time has been checked by the caller, the clock is in range.
+	ts, _ := timeOf(t)
+	now := uint64(ts)
+
+	if customTime != nil {
+		// A custom time says nothing about the clock, so it is kept
+		// apart from the last time of the clock.  Repeating it still
+		// changes the clock sequence.
+		if now <= lastCustom {
+			clockSeq = ((clockSeq + 1) & 0x3fff) | 0x8000
+		}
+		lastCustom = now
+		return Time(now), clockSeq, nil
+	}
+
+	if sharedClock != nil {
+		// Other processes may have used this time with another clock
+		// sequence, so move past the last time any of them used.
+		shared, err := advanceShared(sharedV1Offset, int64(now))
+		if err != nil {
+			return 0, 0, err
+		}
+		lasttime = uint64(shared)
+		return Time(shared), clockSeq, nil
+	}
+
+	if now <= lasttime {
+		switch {
+		case tickCounter && !back:
+			// The same tick as the last time, or still catching up
+			// with the times used ahead of the clock.
+			now = lasttime + 1
+		case now < lasttime && clockPolicy != ClockDefault:
+			// Apply the policy set by SetClockPolicy.
+			var err error
+			if now, err = v1Behind(now); err != nil {
+				return 0, 0, err
+			}
+		}
 	}
-	now := uint64(t.UnixNano()/100) + g1582ns100
 
 	// If time has gone backwards with this clock sequence then we
 	// increment the clock sequence
@@ -71,6 +184,27 @@ func getTime(customTime *time.Time) (Time, uint16, error) {
 	return Time(now), clockSeq, nil
 }
 
+// EnableTickCounter makes Version 1 and 6 UUIDs generated on the same 100ns
+// tick of the clock take the following ticks, as permitted by RFC 9562 section
+// 6.2 for clocks too coarse for the rate of generation, rather than each get a
+// new clock sequence.  The clock sequence then only changes when the clock goes
+// backwards, as it is meant to.  Under heavy load the time of the UUIDs may run
+// ahead of the clock, until the rate of generation drops below one UUID per
+// 100ns.  NewV6WithTime neither uses nor moves the tick counter.
+func EnableTickCounter() {
+	defer timeMu.Unlock()
+	timeMu.Lock()
+	tickCounter = true
+}
+
+// DisableTickCounter restores changing the clock sequence for Version 1 and 6
+// UUIDs generated on the same tick of the clock.
+func DisableTickCounter() {
+	defer timeMu.Unlock()
+	timeMu.Lock()
+	tickCounter = false
+}
+
 // ClockSequence returns the current clock sequence, generating one if not
 // already set.  The clock sequence is only used for Version 1 UUIDs.
 //
@@ -78,6 +212,8 @@ func getTime(customTime *time.Time) (Time, uint16, error) {
 // the last time a UUID was generated.  Unless SetClockSequence is used, a new
 // random clock sequence is generated the first time a clock sequence is
 // requested by ClockSequence, GetTime, or NewUUID.  (section 4.2.1.1)
+//
+// ClockSequence returns -1 if the random number generator fails.
 func ClockSequence() int {
 	defer timeMu.Unlock()
 	timeMu.Lock()
@@ -86,23 +222,30 @@ func ClockSequence() int {
 
 func clockSequence() int {
 	if clockSeq == 0 {
-		setClockSequence(-1)
+		if err := setClockSequence(-1); err != nil {
+			return -1
+		}
 	}
 	return int(clockSeq & 0x3fff)
 }
 
 // SetClockSequence sets the clock sequence to the lower 14 bits of seq.  Setting to
-// -1 causes a new sequence to be generated.
+// -1 causes a new sequence to be generated.  If the random number generator
+// fails the clock sequence is left unset, so that the next Version 1 or 6 UUID
+// tries again and returns the error.
 func SetClockSequence(seq int) {
 	defer timeMu.Unlock()
 	timeMu.Lock()
-	setClockSequence(seq)
+	setClockSequence(seq) //nolint:errcheck
 }
 
-func setClockSequence(seq int) {
+func setClockSequence(seq int) error {
 	if seq == -1 {
 		var b [2]byte
-		randomBits(b[:]) // clock sequence
+		if err := randomBits(b[:]); err != nil { // clock sequence
+			clockSeq = 0
+			return err
+		}
 		seq = int(b[0])<<8 | int(b[1])
 	}
 	oldSeq := clockSeq
@@ -110,13 +253,22 @@ func setClockSequence(seq int) {
 	if oldSeq != clockSeq {
 		lasttime = 0
 	}
+	return nil
 }
 
 // Time returns the time in 100s of nanoseconds since 15 Oct 1582 encoded in
-// uuid.  The time is only defined for version 1, 2, 6 and 7 UUIDs.
+// uuid.  The time is only defined for version 1, 2, 6 and 7 UUIDs, and for the
+// version 8 UUIDs returned by FromSnowflake, FromObjectID, NewTimeHash and
+// Signer.New.  The time of a version 8 UUID is only meaningful if it was
+// created by this package: other version 8 UUIDs may have the bits of one of
+// these layouts by chance, and Time then returns a time they do not hold.
 func (uuid UUID) Time() Time {
 	var t Time
-	switch uuid.Version() {
+	version := uuid.Version()
+	if uuid.v8HasTime() {
+		version = 7 // same time layout as version 7
+	}
+	switch version {
 	case 6:
 		time := int64(binary.BigEndian.Uint32(uuid[0:4])) << 28
 		time |= int64(binary.BigEndian.Uint16(uuid[4:6])) << 12
@@ -1,6 +1,8 @@
 package uuid
 
 import (
+	"errors"
+	"sync"
 	"testing"
 	"time"
 )
@@ -42,3 +44,96 @@ func TestGetTime(t *testing.T) {
 		})
 	}
 }
+
+func TestUnixTimeBefore1970(t *testing.T) {
+	for _, want := range []time.Time{
+		time.Date(1969, 12, 31, 23, 59, 59, 900000000, time.UTC),
+		time.Date(1600, 1, 1, 0, 0, 0, 100, time.UTC),
+		time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		ts, ok := timeOf(want)
+		if !ok {
+			t.Fatalf("%v out of range", want)
+		}
+		sec, nsec := ts.UnixTime()
+		if nsec < 0 || nsec > 999999999 {
+			t.Errorf("%v: got nsec %d", want, nsec)
+		}
+		if got := time.Unix(sec, nsec); !got.Equal(want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestTimeRange(t *testing.T) {
+	for _, v := range []Version{1, 6} {
+		min, max, ok := TimeRange(v)
+		if !ok {
+			t.Fatalf("TimeRange(%d) not ok", v)
+		}
+		if want := time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC); !min.Equal(want) {
+			t.Errorf("TimeRange(%d) min %v, want %v", v, min, want)
+		}
+		if max.Year() != 5236 {
+			t.Errorf("TimeRange(%d) max %v, want the year 5236", v, max)
+		}
+		if ts, ok := timeOf(max); !ok || ts != maxTime {
+			t.Errorf("timeOf(%v) = %d, %v, want %d", max, ts, ok, Time(maxTime))
+		}
+	}
+	min, max, ok := TimeRange(2)
+	if ts, _ := timeOf(max); !ok || min.Year() != 1582 || ts != maxTime&^(1<<32-1) {
+		t.Errorf("TimeRange(2) = %v, %v, %v", min, max, ok)
+	}
+	min, max, ok = TimeRange(7)
+	if !ok || min.Unix() != 0 || max.UnixMilli() != 1<<48-1 || max.Year() != 10889 {
+		t.Errorf("TimeRange(7) = %v, %v, %v", min, max, ok)
+	}
+	if _, _, ok := TimeRange(4); ok {
+		t.Error("TimeRange(4) ok")
+	}
+}
+
+func TestNewV6WithTimeRange(t *testing.T) {
+	min, max, _ := TimeRange(6)
+	for _, tm := range []time.Time{min, max} {
+		uuid, err := NewV6WithTime(&tm)
+		if err != nil {
+			t.Fatalf("NewV6WithTime(%v): %v", tm, err)
+		}
+		if got := time.Unix(uuid.Time().UnixTime()); !got.Equal(tm) {
+			t.Errorf("%s: got time %v, want %v", uuid, got, tm)
+		}
+	}
+	for _, tm := range []time.Time{min.Add(-100), max.Add(100), time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)} {
+		_, err := NewV6WithTime(&tm)
+		if !errors.Is(err, ErrTimeRange) {
+			t.Errorf("NewV6WithTime(%v) got error %v, want %v", tm, err, ErrTimeRange)
+		}
+		var e TimeRangeError
+		if !errors.As(err, &e) || e.Version != 6 || !e.Time.Equal(tm) {
+			t.Errorf("NewV6WithTime(%v) got error %#v", tm, err)
+		}
+	}
+}
+
+// TestNewV6WithTimeRace is meant for go test -race.
+func TestNewV6WithTimeRace(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			now := time.Now()
+			Must(NewV6WithTime(&now))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			Must(NewUUID())
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+)
+
+// NewTimeHash returns a UUID that is both name-based, like NewSHA1, and
+// time-ordered, like NewV7: the Unix millisecond time of t is followed by the
+// SHA-256 hash of space concatenated with data.  The same space, t and data
+// always give the same UUID, so re-importing a record with a creation time
+// and a legacy key gives it the same ID, and the IDs sort by creation time.
+//
+// The UUID is a version 8 UUID holding 70 bits of the hash, unless version is
+// 7.  A version 7 UUID holds 74 bits of the hash in place of the random bits
+// RFC 9562 calls for, so it is only as unpredictable as data.  Any other
+// version is an error, as is a t outside the range given by TimeRange(7).
+func NewTimeHash(space UUID, t time.Time, data []byte, version Version) (UUID, error) {
+	var uuid UUID
+	if version != 7 && version != 8 {
+		return uuid, fmt.Errorf("invalid time hash version %d", version)
+	}
+	milli, err := v7Milli(t, version)
+	if err != nil {
+		return uuid, err
+	}
+	h := sha256.New()
+	h.Write(space[:]) //nolint:errcheck
+	h.Write(data)     //nolint:errcheck
+	var sum [sha256.Size]byte
+	h.Sum(sum[:0])
+
+	if version == 8 {
+		copy(uuid[7:], sum[:9])
+		makeV8(&uuid, milli, v8TimeHash)
+		return uuid, nil
+	}
+	// The worker ID of SetWorkerID is not placed, the UUID depends only
+	// on the arguments.
+	copy(uuid[6:], sum[:10])
+	putMilli(&uuid, milli)
+	uuid[6] = 0x70 | (uuid[6] & 0x0f) // Version 7
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant is 10
+	return uuid, nil
+}
+
+// v7Milli returns the Unix millisecond time of t, or a TimeRangeError if it
+// does not fit in a UUID of version with the layout of Version 7.
+func v7Milli(t time.Time, version Version) (int64, error) {
+	milli := t.UnixMilli()
+	if milli < 0 || milli > maxV7Milli {
+		return 0, TimeRangeError{Version: version, Time: t}
+	}
+	return milli, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewTimeHash(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)
+	name := []byte("https://example.com/rows/42")
+	for _, tt := range []struct {
+		version Version
+		want    string
+	}{
+		{8, "018cc820-d88e-8362-b9f0-839db875cdcf"},
+		{7, "018cc820-d88e-72b9-b083-9db875cdcf4d"},
+	} {
+		uuid, err := NewTimeHash(NameSpaceURL, created, name, tt.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if uuid.String() != tt.want {
+			t.Errorf("version %d: got %s, want %s", tt.version, uuid, tt.want)
+		}
+		if uuid.Version() != tt.version || uuid.Variant() != RFC4122 {
+			t.Errorf("%s: got version %s, variant %s", uuid, uuid.Version(), uuid.Variant())
+		}
+		if got := time.Unix(uuid.Time().UnixTime()); !got.Equal(created) {
+			t.Errorf("%s: got time %v, want %v", uuid, got, created)
+		}
+		if other := Must(NewTimeHash(NameSpaceOID, created, name, tt.version)); other == uuid {
+			t.Errorf("different name spaces gave %s", uuid)
+		}
+		later := Must(NewTimeHash(NameSpaceURL, created.Add(time.Millisecond), []byte("a"), tt.version))
+		if Compare(later, uuid) <= 0 {
+			t.Errorf("%s sorts before %s", later, uuid)
+		}
+	}
+}
+
+func TestNewTimeHashWorkerID(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	want := Must(NewTimeHash(NameSpaceDNS, created, []byte("example.com"), 7))
+	SetWorkerID(0x3ff, 10)
+	defer SetWorkerID(0, 0)
+	if got := Must(NewTimeHash(NameSpaceDNS, created, []byte("example.com"), 7)); got != want {
+		t.Errorf("worker ID changed %s to %s", want, got)
+	}
+}
+
+func TestNewTimeHashErrors(t *testing.T) {
+	if _, err := NewTimeHash(NameSpaceDNS, time.Now(), nil, 5); err == nil {
+		t.Error("version 5 accepted")
+	}
+	for _, tm := range []time.Time{time.Unix(-1, 0), time.UnixMilli(maxV7Milli + 1)} {
+		if _, err := NewTimeHash(NameSpaceDNS, tm, nil, 8); !errors.Is(err, ErrTimeRange) {
+			t.Errorf("%v: got error %v, want %v", tm, err, ErrTimeRange)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"io"
+)
+
+// redacted is what a Token is formatted and logged as.
+const redacted = "uuid.Token(REDACTED)"
+
+// A Token is a random (Version 4) UUID used as a secret, such as a password
+// reset or API token.  Its random bits are always read from crypto/rand,
+// bypassing the randomness pool and the generator set by SetRand.  Tokens are
+// compared in constant time by Equal, and are redacted when formatted with
+// the fmt package or, with Go 1.21 or later, logged with log/slog, so they do
+// not end up in logs.  Use Reveal to obtain the string form to hand out.
+type Token struct {
+	uuid UUID
+}
+
+// NewToken returns a new Token read from crypto/rand.  On error, NewToken
+// returns the zero Token and an error.
+func NewToken() (Token, error) {
+	var t Token
+	if _, err := io.ReadFull(rand.Reader, t.uuid[:]); err != nil {
+		return Token{}, err
+	}
+	t.uuid[6] = (t.uuid[6] & 0x0f) | 0x40 // Version 4
+	t.uuid[8] = (t.uuid[8] & 0x3f) | 0x80 // Variant is 10
+	return t, nil
+}
+
+// ParseToken parses the string form of a Token returned by Reveal.  It accepts
+// the same forms as Parse.
+func ParseToken(s string) (Token, error) {
+	uuid, err := Parse(s)
+	if err != nil {
+		return Token{}, err
+	}
+	return Token{uuid: uuid}, nil
+}
+
+// Equal reports whether t and u are the same token, in time independent of
+// their contents.
+func (t Token) Equal(u Token) bool {
+	return subtle.ConstantTimeCompare(t.uuid[:], u.uuid[:]) == 1
+}
+
+// EqualString reports whether s is the string form of t.  Only the comparison
+// with t takes constant time; parsing s does not, which reveals nothing
+// about t.
+func (t Token) EqualString(s string) bool {
+	u, err := ParseToken(s)
+	if err != nil {
+		return false
+	}
+	return t.Equal(u)
+}
+
+// IsZero reports whether t is the zero Token.
+func (t Token) IsZero() bool {
+	return t.Equal(Token{})
+}
+
+// Zero overwrites t with zeros, for when it is no longer needed.  Copies of
+// t, including the strings returned by Reveal, are not affected.
+func (t *Token) Zero() {
+	for i := range t.uuid {
+		t.uuid[i] = 0
+	}
+}
+
+// Reveal returns the string form of t, xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func (t Token) Reveal() string {
+	return t.uuid.String()
+}
+
+// UUID returns t as a UUID, which is no longer redacted.
+func (t Token) UUID() UUID {
+	return t.uuid
+}
+
+// String returns a redacted placeholder rather than t.
+func (t Token) String() string {
+	return redacted
+}
+
+// GoString returns a redacted placeholder rather than t.
+func (t Token) GoString() string {
+	return redacted
+}
+
+// Format implements fmt.Formatter and formats t as a redacted placeholder
+// with every verb.
+func (t Token) Format(f fmt.State, verb rune) {
+	io.WriteString(f, redacted) //nolint:errcheck
+}
@@ -0,0 +1,15 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+// +build go1.21
+
+package uuid
+
+import "log/slog"
+
+// LogValue implements slog.LogValuer and logs t as a redacted placeholder.
+func (t Token) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+// +build go1.21
+
+package uuid
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func TestTokenLogValue(t *testing.T) {
+	tok, _ := NewToken()
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("reset", "token", tok)
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("reset", "token", tok)
+	checkRedacted(t, tok, []string{buf.String()})
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestToken(t *testing.T) {
+	// Tokens ignore SetRand and the pool.
+	SetRand(bytes.NewReader(make([]byte, 1<<16)))
+	EnableRandPool()
+	defer func() {
+		DisableRandPool()
+		SetRand(nil)
+	}()
+
+	a, err := NewToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := NewToken()
+	if a.Equal(b) || a.IsZero() {
+		t.Fatalf("tokens %s and %s not random", a.Reveal(), b.Reveal())
+	}
+	if u := a.UUID(); u.Version() != 4 || u.Variant() != RFC4122 {
+		t.Errorf("%s: version %s, variant %s", u, u.Version(), u.Variant())
+	}
+
+	s := a.Reveal()
+	c, err := ParseToken(s)
+	if err != nil || !c.Equal(a) || c.UUID() != a.UUID() {
+		t.Errorf("ParseToken(%s) = %s, %v", s, c.Reveal(), err)
+	}
+	if !a.EqualString(s) || !a.EqualString(strings.ToUpper(s)) || a.EqualString(b.Reveal()) || a.EqualString("junk") {
+		t.Error("EqualString wrong")
+	}
+
+	c.Zero()
+	if !c.IsZero() || c.Equal(a) {
+		t.Errorf("Zero left %s", c.Reveal())
+	}
+	if a.IsZero() {
+		t.Error("Zero changed a copy")
+	}
+}
+
+func TestTokenRedacted(t *testing.T) {
+	tok, _ := NewToken()
+	var out []string
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%X", "%d"} {
+		out = append(out, fmt.Sprintf(format, tok))
+		out = append(out, fmt.Sprintf(format, struct{ T Token }{tok}))
+	}
+	out = append(out, tok.String(), fmt.Sprint(&tok))
+
+	checkRedacted(t, tok, out)
+}
+
+// checkRedacted checks that the strings out hold a redacted placeholder and
+// not tok.
+func checkRedacted(t *testing.T, tok Token, out []string) {
+	t.Helper()
+	secret := tok.Reveal()
+	hexSecret := strings.ReplaceAll(secret, "-", "")
+	for _, s := range out {
+		low := strings.ToLower(s)
+		if strings.Contains(low, secret) || strings.Contains(low, hexSecret) || strings.Contains(low, hexSecret[:8]) {
+			t.Errorf("token leaked in %q", s)
+		}
+		if !strings.Contains(s, "REDACTED") {
+			t.Errorf("%q is not redacted", s)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// A ULID (https://github.com/ulid/spec) is, like a UUID, a 128 bit value.  It
+// has a 48 bit big-endian Unix millisecond timestamp followed by 80 random
+// bits and is written as 26 characters of Crockford's base32.  ULIDs are held
+// in a UUID unchanged, so the ULID 01ARZ3NDEKTSV4RRFFQ69G5FAV and the UUID
+// 01563e3a-b5d3-d676-4c61-efb99302bd5b are the same value.  The layout of a
+// ULID matches that of a version 7 UUID without the version and variant bits.
+
+// ErrInvalidULID is returned when a string is not a valid ULID.
+var ErrInvalidULID = errors.New("invalid ULID format")
+
+// crockford is Crockford's base32 alphabet as used by ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordValues returns the value of a base32 digit or 255.  Lower case
+// letters are accepted and, following Crockford, I and L decode as 1 and O as
+// 0.
+var crockfordValues = func() (v [256]byte) {
+	for i := range v {
+		v[i] = 255
+	}
+	for i := 0; i < len(crockford); i++ {
+		c := crockford[i]
+		v[c] = byte(i)
+		if c >= 'A' && c <= 'Z' {
+			v[c+'a'-'A'] = byte(i)
+		}
+	}
+	v['I'], v['i'], v['L'], v['l'] = 1, 1, 1, 1
+	v['O'], v['o'] = 0, 0
+	return v
+}()
+
+// ParseULID decodes the 26 character ULID s into a UUID.  Decoding is case
+// insensitive.  No version or variant bits are set, see ULIDToV7.
+func ParseULID(s string) (UUID, error) {
+	var uuid UUID
+	if len(s) != 26 || crockfordValues[s[0]] > 7 {
+		// The first character holds only the top 3 bits.
+		return uuid, ErrInvalidULID
+	}
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		v := crockfordValues[s[i]]
+		if v == 255 {
+			return uuid, ErrInvalidULID
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	return FromUint64Pair(hi, lo), nil
+}
+
+// ULID returns the 26 character ULID form of uuid.
+func (uuid UUID) ULID() string {
+	var buf [26]byte
+	hi, lo := uuid.Uint64Pair()
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buf[:])
+}
+
+// ULIDTime returns the time held in the first 48 bits of a ULID.  For a
+// version 7 UUID this is the same time as reported by Time.
+func (uuid UUID) ULIDTime() time.Time {
+	hi, _ := uuid.Uint64Pair()
+	return time.UnixMilli(int64(hi >> 16))
+}
+
+// ULIDToV7 returns the version 7 UUID closest to the ULID held in id by
+// setting the version and variant bits.  Those 6 bits of the ULID are lost and
+// returned in lost, the 4 replaced version bits followed by the 2 replaced
+// variant bits.  lost is 0x1e if the ULID was already a version 7 UUID.
+// RestoreULID reverses ULIDToV7.
+func ULIDToV7(id UUID) (v7 UUID, lost byte) {
+	lost = id[6]>>4<<2 | id[8]>>6
+	id[6] = (id[6] & 0x0f) | 0x70 // Version 7
+	id[8] = (id[8] & 0x3f) | 0x80 // Variant is 10
+	return id, lost
+}
+
+// RestoreULID returns the ULID that ULIDToV7 turned into v7 and lost.
+func RestoreULID(v7 UUID, lost byte) UUID {
+	v7[6] = (v7[6] & 0x0f) | (lost>>2)<<4
+	v7[8] = (v7[8] & 0x3f) | lost<<6
+	return v7
+}
+
+// NewULID returns a new ULID based on the current time.  ULIDs are generated
+// with the same clock as NewV7: the 48 bit millisecond timestamp is followed
+// by the 12 bit sub-millisecond sequence and 68 random bits.  Every ULID
+// returned in this process sorts after the ones before it, and no ULID shares
+// its timestamp and sequence with a version 7 UUID.  This is compatible with,
+// though not the same as, the monotonic ULID generators that increment the
+// random bits within a millisecond.
+//
+// Uses the randomness pool if it was enabled with EnableRandPool.
+// On error, NewULID returns Nil and an error.
+func NewULID() (UUID, error) {
+	uuid, err := NewRandom()
+	if err != nil {
+		return uuid, err
+	}
+	if err := makeULID(uuid[:]); err != nil {
+		return Nil, err
+	}
+	return uuid, nil
+}
+
+// NewULIDFromReader is like NewULID but reads its random bits from r.
+func NewULIDFromReader(r io.Reader) (UUID, error) {
+	var uuid UUID
+	if _, err := io.ReadFull(r, uuid[:]); err != nil {
+		return Nil, err
+	}
+	if err := makeULID(uuid[:]); err != nil {
+		return Nil, err
+	}
+	return uuid, nil
+}
+
+// makeULID fills the timestamp and sequence of a ULID, replacing the version
+// and variant bits left by NewRandom with random ones.
+func makeULID(uuid []byte) error {
+	_ = uuid[15] // bounds check
+
+	t, s, err := getV7Time()
+	if err != nil {
+		return err
+	}
+
+	// The low bits of byte 6, overwritten by the sequence, are random and
+	// take the place of the variant bits.
+	uuid[8] = uuid[6]<<6 | uuid[8]&0x3f
+
+	uuid[0] = byte(t >> 40)
+	uuid[1] = byte(t >> 32)
+	uuid[2] = byte(t >> 24)
+	uuid[3] = byte(t >> 16)
+	uuid[4] = byte(t >> 8)
+	uuid[5] = byte(t)
+
+	uuid[6] = byte(s >> 4)
+	uuid[7] = byte(s<<4) | (uuid[7] & 0x0f)
+	return nil
+}
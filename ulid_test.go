@@ -0,0 +1,112 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseULID(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAV", "01563e3a-b5d3-d676-4c61-efb99302bd5b"},
+		{"01arz3ndektsv4rrffq69g5fav", "01563e3a-b5d3-d676-4c61-efb99302bd5b"},
+		{"00000000000000000000000000", "00000000-0000-0000-0000-000000000000"},
+		{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+		{"0000000000000000000000000O", "00000000-0000-0000-0000-000000000000"},
+		{"0000000000000000000000000L", "00000000-0000-0000-0000-000000000001"},
+	} {
+		uuid, err := ParseULID(tt.in)
+		if err != nil {
+			t.Errorf("ParseULID(%q): %v", tt.in, err)
+			continue
+		}
+		if uuid.String() != tt.want {
+			t.Errorf("ParseULID(%q) got %s, want %s", tt.in, uuid, tt.want)
+		}
+		if strings.ContainsAny(tt.in, "OL") {
+			continue
+		}
+		if s := uuid.ULID(); s != strings.ToUpper(tt.in) {
+			t.Errorf("%s.ULID() got %s, want %s", uuid, s, strings.ToUpper(tt.in))
+		}
+	}
+	for _, in := range []string{
+		"",
+		"01ARZ3NDEKTSV4RRFFQ69G5FA",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAVX",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAU",
+		"80000000000000000000000000",
+	} {
+		if _, err := ParseULID(in); err != ErrInvalidULID {
+			t.Errorf("ParseULID(%q) got error %v, want %v", in, err, ErrInvalidULID)
+		}
+	}
+}
+
+func TestULIDToV7(t *testing.T) {
+	id, _ := ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	v7, lost := ULIDToV7(id)
+	if v := v7.Version(); v != 7 {
+		t.Errorf("%s: version %s expected 7", v7, v)
+	}
+	if v := v7.Variant(); v != RFC4122 {
+		t.Errorf("%s: variant %s expected RFC4122", v7, v)
+	}
+	if got := RestoreULID(v7, lost); got != id {
+		t.Errorf("RestoreULID got %s, want %s", got, id)
+	}
+	if !v7.ULIDTime().Equal(id.ULIDTime()) {
+		t.Errorf("ULIDToV7 changed the time from %v to %v", id.ULIDTime(), v7.ULIDTime())
+	}
+
+	uuid := Must(NewV7())
+	if got, lost := ULIDToV7(uuid); got != uuid || lost != 0x1e {
+		t.Errorf("ULIDToV7(%s) got %s %#x, want %s 0x1e", uuid, got, lost, uuid)
+	}
+}
+
+func TestNewULID(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	u1 := Must(NewULID())
+	if ut := u1.ULIDTime(); ut.Before(now) || ut.After(now.Add(time.Second)) {
+		t.Errorf("ULIDTime got %v, want about %v", ut, now)
+	}
+	for i := 0; i < 10000; i++ {
+		// Generating version 7 UUIDs advances the shared clock.
+		Must(NewV7())
+		u2 := Must(NewULID())
+		if u2.ULID() <= u1.ULID() {
+			t.Fatalf("monotonicity failed at #%d: %s(next) <= %s(before)", i, u2.ULID(), u1.ULID())
+		}
+		u1 = u2
+	}
+}
+
+func TestNewULIDFromReader(t *testing.T) {
+	r := strings.NewReader("8059ddhdle77cb52")
+	if _, err := NewULIDFromReader(r); err != nil {
+		t.Errorf("failed generating ULID from a reader: %v", err)
+	}
+	if _, err := NewULIDFromReader(r); err == nil {
+		t.Errorf("expecting an error as reader has no more bytes")
+	}
+
+	// The top bits of byte 8 do not depend on byte 9.
+	b := make([]byte, 16)
+	b[6], b[9] = 0x02, 0xff
+	u, err := NewULIDFromReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u[8] != 0x80 || u[9] != 0xff {
+		t.Errorf("got bytes 8 and 9 %02x%02x, want 80ff", u[8], u[9])
+	}
+}
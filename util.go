@@ -9,11 +9,11 @@ import (
 	"io"
 )
 
-// randomBits completely fills slice b with random data.
-func randomBits(b []byte) {
-	if _, err := io.ReadFull(rander, b); err != nil {
-		panic(err.Error()) // rand should never fail
-	}
+// randomBits completely fills slice b with random data.  An error is returned
+// if the random number generator fails.
+func randomBits(b []byte) error {
+	_, err := io.ReadFull(randSource(), b)
+	return err
 }
 
 // xvalues returns the value of a byte as a hexadecimal digit or 255.
@@ -340,11 +340,15 @@ func (v Variant) String() string {
 
 // SetRand sets the random number generator to r, which implements io.Reader.
 // If r.Read returns an error when the package requests random data then
-// a panic will be issued.
+// the error is returned by the function generating the UUID, or, for New,
+// NewString and other functions without an error result, a panic will be
+// issued.
 //
 // Calling SetRand with nil sets the random number generator to the default
-// generator.
+// generator.  SetRand clears a failure of the health tests enabled by
+// EnableRandHealthTests.
 func SetRand(r io.Reader) {
+	resetRandHealth()
 	if r == nil {
 		rander = rand.Reader
 		return
@@ -358,7 +362,8 @@ func SetRand(r io.Reader) {
 // may improve the UUID generation throughput significantly.
 //
 // Since the pool is stored on the Go heap, this feature may be a bad fit
-// for security sensitive applications.
+// for security sensitive applications.  Use NewToken for UUIDs that are
+// secrets.
 //
 // Both EnableRandPool and DisableRandPool are not thread-safe and should
 // only be called when there is no possibility that New or any other
@@ -501,6 +501,49 @@ func TestBadRand(t *testing.T) {
 	}
 }
 
+type failRand struct{}
+
+func (failRand) Read([]byte) (int, error) {
+	return 0, errors.New("rand failed")
+}
+
+func TestFailRand(t *testing.T) {
+	SetRand(failRand{})
+	defer func() {
+		SetRand(nil)
+		DisableRandPool()
+		SetClockSequence(-1)
+	}()
+
+	if _, _, err := (RandomNode{}).NodeID(); err == nil {
+		t.Error("RandomNode did not fail")
+	}
+	SetClockSequence(-1)
+	if seq := ClockSequence(); seq != -1 {
+		t.Errorf("ClockSequence() = %d, want -1", seq)
+	}
+	for name, f := range map[string]func() (UUID, error){
+		"NewUUID":       NewUUID,
+		"NewV6":         NewV6,
+		"NewV6WithTime": func() (UUID, error) { return NewV6WithTime(nil) },
+		"NewRandom":     NewRandom,
+		"NewRandomPool": func() (UUID, error) { EnableRandPool(); return NewRandom() },
+	} {
+		if uuid, err := f(); err == nil || uuid != Nil {
+			t.Errorf("%s() = %s, %v, want an error", name, uuid, err)
+		}
+	}
+
+	// The clock sequence is set once the generator works again.
+	SetRand(nil)
+	if _, err := NewUUID(); err != nil {
+		t.Fatal(err)
+	}
+	if seq := ClockSequence(); seq == -1 {
+		t.Error("clock sequence not set")
+	}
+}
+
 func TestSetRand(t *testing.T) {
 	myString := "805-9dd6-1a877cb526c678e71d38-7122-44c0-9b7c-04e7001cc78783ac3e82-47a3-4cc3-9951-13f3339d88088f5d685a-11f7-4078-ada9-de44ad2daeb7"
 
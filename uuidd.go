@@ -0,0 +1,130 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The uuidd protocol lets many processes obtain time-based UUIDs from one
+// daemon, which owns the clock sequence, Node ID and last time, in the spirit
+// of uuidd(8) from util-linux.  The daemon is run by cmd/uuidd and listens on a
+// Unix domain socket.  A request is 5 bytes:
+//
+//	version (1 byte): 1, 6 or 7
+//	count   (4 bytes, big-endian): number of UUIDs wanted, 1 to MaxDaemonBulk
+//
+// and a response is:
+//
+//	status  (1 byte): 0 on success
+//	length  (4 bytes, big-endian): number of UUIDs, or of bytes of error text
+//	body    (16 bytes per UUID, or the error text if status is not 0)
+//
+// A connection may carry any number of requests.
+
+// DefaultDaemonSocket is the socket used by DaemonClient and cmd/uuidd if no
+// other is given.
+const DefaultDaemonSocket = "/run/go-uuidd.sock"
+
+// MaxDaemonBulk is the most UUIDs returned for one request.
+const MaxDaemonBulk = 4096
+
+// Status bytes of uuidd responses.
+const (
+	daemonOK         = 0
+	daemonBadRequest = 1
+	daemonFailed     = 2
+)
+
+// A DaemonError is an error reported by the uuidd daemon.
+type DaemonError struct {
+	Msg string
+}
+
+func (e *DaemonError) Error() string {
+	return "uuidd daemon: " + e.Msg
+}
+
+// ErrDaemonNotRunning is returned by a DaemonClient with NoFallback set if
+// the daemon is not running.
+var ErrDaemonNotRunning = errors.New("uuidd daemon not running")
+
+// A DaemonClient obtains time-based UUIDs from the daemon run by cmd/uuidd.
+// If the daemon is not running, the UUIDs are generated in this process
+// instead.  The zero value uses DefaultDaemonSocket.  A DaemonClient is safe
+// for concurrent use.
+type DaemonClient struct {
+	// Path is the socket of the daemon, DefaultDaemonSocket if "".
+	Path string
+
+	// Timeout limits the time a request to the daemon may take.  There is
+	// no limit if it is 0.
+	Timeout time.Duration
+
+	// NoFallback makes requests fail, rather than generate UUIDs in this
+	// process, if the daemon is not running.
+	NoFallback bool
+}
+
+// New returns a new UUID of version 1, 6 or 7 from the daemon.
+func (c *DaemonClient) New(version Version) (UUID, error) {
+	uuids, err := c.NewBulk(version, 1)
+	if err != nil {
+		return Nil, err
+	}
+	return uuids[0], nil
+}
+
+// NewBulk returns n new UUIDs of version 1, 6 or 7 from the daemon, generated
+// one after the other.  n may not be more than MaxDaemonBulk.
+func (c *DaemonClient) NewBulk(version Version, n int) (UUIDs, error) {
+	if err := checkDaemonRequest(version, n); err != nil {
+		return nil, err
+	}
+	path := c.Path
+	if path == "" {
+		path = DefaultDaemonSocket
+	}
+	uuids, err := c.request(path, version, n)
+	if err == ErrDaemonNotRunning && !c.NoFallback {
+		return generateBulk(version, n)
+	}
+	return uuids, err
+}
+
+// checkDaemonRequest returns an error if n UUIDs of version cannot be
+// requested from the daemon.
+func checkDaemonRequest(version Version, n int) error {
+	switch version {
+	case 1, 6, 7:
+	default:
+		return fmt.Errorf("uuidd cannot generate version %d UUIDs", version)
+	}
+	if n < 1 || n > MaxDaemonBulk {
+		return fmt.Errorf("invalid uuidd request count %d", n)
+	}
+	return nil
+}
+
+// generateBulk returns n new UUIDs of version generated in this process.
+func generateBulk(version Version, n int) (UUIDs, error) {
+	gen := NewUUID
+	switch version {
+	case 6:
+		gen = NewV6
+	case 7:
+		gen = NewV7
+	}
+	uuids := make(UUIDs, n)
+	for i := range uuids {
+		var err error
+		if uuids[i], err = gen(); err != nil {
+			return nil, err
+		}
+	}
+	return uuids, nil
+}
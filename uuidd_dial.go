@@ -0,0 +1,20 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !js && !plan9
+// +build !js,!plan9
+
+package uuid
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// notListening reports whether the dial error err means that no daemon is
+// listening on the socket.
+func notListening(err error) bool {
+	return errors.Is(err, os.ErrNotExist) || errors.Is(err, syscall.ECONNREFUSED)
+}
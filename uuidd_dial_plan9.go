@@ -0,0 +1,15 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build plan9
+// +build plan9
+
+package uuid
+
+// notListening reports whether the dial error err means that no daemon is
+// listening on the socket.  Plan 9 has no Unix domain sockets, so no daemon
+// ever is.
+func notListening(err error) bool {
+	return true
+}
@@ -0,0 +1,14 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build js
+// +build js
+
+package uuid
+
+// request reports the daemon as not running, as there are no Unix domain
+// sockets in the browser.
+func (c *DaemonClient) request(path string, version Version, n int) (UUIDs, error) {
+	return nil, ErrDaemonNotRunning
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !js
+// +build !js
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// request asks the daemon listening on path for n UUIDs of version.
+func (c *DaemonClient) request(path string, version Version, n int) (UUIDs, error) {
+	conn, err := net.DialTimeout("unix", path, c.Timeout)
+	if err != nil {
+		if notListening(err) {
+			return nil, ErrDaemonNotRunning
+		}
+		return nil, err
+	}
+	defer conn.Close()
+	if c.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(c.Timeout)) //nolint:errcheck
+	}
+
+	var req [5]byte
+	req[0] = byte(version)
+	binary.BigEndian.PutUint32(req[1:], uint32(n))
+	if _, err := conn.Write(req[:]); err != nil {
+		return nil, err
+	}
+	var hdr [5]byte
+	if _, err := io.ReadFull(conn, hdr[:]); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(hdr[1:])
+	if hdr[0] != daemonOK {
+		if length > 1024 {
+			return nil, &DaemonError{fmt.Sprintf("status %d", hdr[0])}
+		}
+		msg := make([]byte, length)
+		if _, err := io.ReadFull(conn, msg); err != nil {
+			return nil, err
+		}
+		return nil, &DaemonError{string(msg)}
+	}
+	if length != uint32(n) {
+		return nil, &DaemonError{fmt.Sprintf("got %d UUIDs, want %d", length, n)}
+	}
+	uuids := make(UUIDs, n)
+	for i := range uuids {
+		if _, err := io.ReadFull(conn, uuids[i][:]); err != nil {
+			return nil, err
+		}
+	}
+	return uuids, nil
+}
+
+// ServeDaemon accepts connections on l and answers the uuidd requests they
+// carry with UUIDs generated in this process.  It is the server side of
+// DaemonClient and is run by cmd/uuidd.  ServeDaemon returns when l.Accept
+// fails, for example because l was closed.
+func ServeDaemon(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go serveDaemonConn(conn)
+	}
+}
+
+// serveDaemonConn answers the requests on conn until it is closed or sends a
+// bad request.
+func serveDaemonConn(conn net.Conn) {
+	defer conn.Close()
+	var req [5]byte
+	for {
+		if _, err := io.ReadFull(conn, req[:]); err != nil {
+			return
+		}
+		version, n := Version(req[0]), int(binary.BigEndian.Uint32(req[1:]))
+		if err := checkDaemonRequest(version, n); err != nil {
+			// The stream may be out of step, so give up on it.
+			writeDaemonError(conn, daemonBadRequest, err)
+			return
+		}
+		uuids, err := generateBulk(version, n)
+		if err != nil {
+			if writeDaemonError(conn, daemonFailed, err) != nil {
+				return
+			}
+			continue
+		}
+		resp := make([]byte, 5, 5+16*n)
+		binary.BigEndian.PutUint32(resp[1:], uint32(n))
+		for _, uuid := range uuids {
+			resp = append(resp, uuid[:]...)
+		}
+		if _, err := conn.Write(resp); err != nil {
+			return
+		}
+	}
+}
+
+// writeDaemonError sends err to the client with status.
+func writeDaemonError(conn net.Conn, status byte, err error) error {
+	msg := err.Error()
+	resp := make([]byte, 5, 5+len(msg))
+	resp[0] = status
+	binary.BigEndian.PutUint32(resp[1:], uint32(len(msg)))
+	_, err = conn.Write(append(resp, msg...))
+	return err
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !js
+// +build !js
+
+package uuid
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func startDaemon(t *testing.T) (path string, stop func()) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets not tested on windows")
+	}
+	path = filepath.Join(t.TempDir(), "uuidd.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("cannot listen on a Unix domain socket: %v", err)
+	}
+	go ServeDaemon(l) //nolint:errcheck
+	return path, func() { l.Close() }
+}
+
+func TestDaemonClient(t *testing.T) {
+	path, stop := startDaemon(t)
+	defer stop()
+
+	c := &DaemonClient{Path: path, Timeout: 5 * time.Second, NoFallback: true}
+	for _, v := range []Version{1, 6, 7} {
+		uuid, err := c.New(v)
+		if err != nil {
+			t.Fatalf("New(%d): %v", v, err)
+		}
+		if uuid.Version() != v {
+			t.Errorf("%s: version %s expected %s", uuid, uuid.Version(), v)
+		}
+		uuids, err := c.NewBulk(v, 100)
+		if err != nil {
+			t.Fatalf("NewBulk(%d): %v", v, err)
+		}
+		if len(uuids) != 100 {
+			t.Fatalf("NewBulk(%d) got %d UUIDs, want 100", v, len(uuids))
+		}
+		seen := map[UUID]bool{uuid: true}
+		for _, u := range uuids {
+			if seen[u] || u.Version() != v {
+				t.Errorf("NewBulk(%d) returned %s", v, u)
+			}
+			seen[u] = true
+		}
+	}
+
+	if _, err := c.New(4); err == nil {
+		t.Error("New(4) succeeded")
+	}
+	if _, err := c.NewBulk(7, MaxDaemonBulk+1); err == nil {
+		t.Error("NewBulk of too many UUIDs succeeded")
+	}
+}
+
+func TestDaemonBadRequest(t *testing.T) {
+	path, stop := startDaemon(t)
+	defer stop()
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.Write([]byte{4, 0, 0, 0, 1}) //nolint:errcheck
+	var hdr [5]byte
+	if _, err := io.ReadFull(conn, hdr[:]); err != nil {
+		t.Fatal(err)
+	}
+	if hdr[0] != daemonBadRequest {
+		t.Errorf("got status %d, want %d", hdr[0], daemonBadRequest)
+	}
+	msg := make([]byte, binary.BigEndian.Uint32(hdr[1:]))
+	if _, err := io.ReadFull(conn, msg); err != nil {
+		t.Fatal(err)
+	}
+	if len(msg) == 0 {
+		t.Error("no error message")
+	}
+}
+
+func TestDaemonClientFallback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.sock")
+	c := &DaemonClient{Path: path}
+	uuid, err := c.New(7)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if uuid.Version() != 7 {
+		t.Errorf("%s: version %s expected 7", uuid, uuid.Version())
+	}
+
+	c.NoFallback = true
+	if _, err := c.New(7); err != ErrDaemonNotRunning {
+		t.Errorf("got error %v, want %v", err, ErrDaemonNotRunning)
+	}
+}
@@ -11,9 +11,9 @@ import (
 // NewUUID returns a Version 1 UUID based on the current NodeID and clock
 // sequence, and the current time.  If the NodeID has not been set by SetNodeID
 // or SetNodeInterface then it will be set automatically.  If the NodeID cannot
-// be set NewUUID returns nil.  If clock sequence has not been set by
-// SetClockSequence then it will be set automatically.  If GetTime fails to
-// return the current NewUUID returns nil and an error.
+// be set NewUUID returns Nil and an error.  If clock sequence has not been set
+// by SetClockSequence then it will be set automatically.  If GetTime fails to
+// return the current NewUUID returns Nil and an error.
 //
 // In most cases, New should be used.
 func NewUUID() (UUID, error) {
@@ -23,6 +23,19 @@ func NewUUID() (UUID, error) {
 		return uuid, err
 	}
 
+	putV1Time(&uuid, now, seq)
+
+	node, err := getNodeID()
+	if err != nil {
+		return Nil, err
+	}
+	copy(uuid[10:], node[:])
+
+	return uuid, nil
+}
+
+// putV1Time fills the time, version and clock sequence of a Version 1 UUID.
+func putV1Time(uuid *UUID, now Time, seq uint16) {
 	timeLow := uint32(now & 0xffffffff)
 	timeMid := uint16((now >> 32) & 0xffff)
 	timeHi := uint16((now >> 48) & 0x0fff)
@@ -32,13 +45,4 @@ func NewUUID() (UUID, error) {
 	binary.BigEndian.PutUint16(uuid[4:], timeMid)
 	binary.BigEndian.PutUint16(uuid[6:], timeHi)
 	binary.BigEndian.PutUint16(uuid[8:], seq)
-
-	nodeMu.Lock()
-	if nodeID == zeroID {
-		setNodeInterface("")
-	}
-	copy(uuid[10:], nodeID[:])
-	nodeMu.Unlock()
-
-	return uuid, nil
 }
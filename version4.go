@@ -38,7 +38,7 @@ func NewString() string {
 //  year and having one duplicate.
 func NewRandom() (UUID, error) {
 	if !poolEnabled {
-		return NewRandomFromReader(rander)
+		return NewRandomFromReader(randSource())
 	}
 	return newRandomFromPool()
 }
@@ -59,7 +59,7 @@ func newRandomFromPool() (UUID, error) {
 	var uuid UUID
 	poolMu.Lock()
 	if poolPos == randPoolSize {
-		_, err := io.ReadFull(rander, pool[:])
+		_, err := io.ReadFull(randSource(), pool[:])
 		if err != nil {
 			poolMu.Unlock()
 			return Nil, err
@@ -18,7 +18,8 @@ import (
 // NewV6 returns a Version 6 UUID based on the current NodeID and clock
 // sequence, and the current time. If the NodeID has not been set by SetNodeID
 // or SetNodeInterface then it will be set automatically. If the NodeID cannot
-// be set NewV6 set NodeID is random bits automatically . If clock sequence has not been set by
+// be set NewV6 set NodeID is random bits automatically, and if that fails NewV6
+// returns Nil and an error. If clock sequence has not been set by
 // SetClockSequence then it will be set automatically. If GetTime fails to
 // return the current NewV6 returns Nil and an error.
 func NewV6() (UUID, error) {
@@ -26,7 +27,7 @@ func NewV6() (UUID, error) {
 	if err != nil {
 		return Nil, err
 	}
-	return generateV6(now, seq), nil
+	return generateV6(now, seq)
 }
 
 // NewV6WithTime returns a Version 6 UUID based on the current NodeID, clock
@@ -35,19 +36,42 @@ func NewV6() (UUID, error) {
 //
 // There is a limit on how many UUIDs can be generated for the same time, so if you
 // are generating multiple UUIDs, it is recommended to increment the time.
+// Custom times do not move the time used by NewUUID and NewV6, nor are they
+// subject to the policy set by SetClockPolicy.
+// If customTime is outside the range given by TimeRange(6), NewV6WithTime
+// returns Nil and a TimeRangeError.
 // If getTime fails to return the current NewV6WithTime returns Nil and an error.
 func NewV6WithTime(customTime *time.Time) (UUID, error) {
+	if customTime != nil {
+		if _, ok := timeOf(*customTime); !ok {
+			return Nil, TimeRangeError{Version: 6, Time: *customTime}
+		}
+	}
+	timeMu.Lock()
 	now, seq, err := getTime(customTime)
+	timeMu.Unlock()
 	if err != nil {
 		return Nil, err
 	}
 
-	return generateV6(now, seq), nil
+	return generateV6(now, seq)
 }
 
-func generateV6(now Time, seq uint16) UUID {
+func generateV6(now Time, seq uint16) (UUID, error) {
 	var uuid UUID
+	putV6Time(&uuid, now, seq)
+
+	node, err := getNodeID()
+	if err != nil {
+		return Nil, err
+	}
+	copy(uuid[10:], node[:])
 
+	return uuid, nil
+}
+
+// putV6Time fills the time, version and clock sequence of a Version 6 UUID.
+func putV6Time(uuid *UUID, now Time, seq uint16) {
 	/*
 	    0                   1                   2                   3
 	    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
@@ -71,13 +95,4 @@ func generateV6(now Time, seq uint16) UUID {
 	binary.BigEndian.PutUint16(uuid[4:], timeMid)
 	binary.BigEndian.PutUint16(uuid[6:], timeLow)
 	binary.BigEndian.PutUint16(uuid[8:], seq)
-
-	nodeMu.Lock()
-	if nodeID == zeroID {
-		setNodeInterface("")
-	}
-	copy(uuid[10:], nodeID[:])
-	nodeMu.Unlock()
-
-	return uuid
 }
@@ -19,13 +19,16 @@ import (
 //
 // NewV7 returns a Version 7 UUID based on the current time(Unix Epoch).
 // Uses the randomness pool if it was enabled with EnableRandPool.
+// The worker ID set by SetWorkerID, if any, is placed in rand_b.
 // On error, NewV7 returns Nil and an error
 func NewV7() (UUID, error) {
 	uuid, err := NewRandom()
 	if err != nil {
 		return uuid, err
 	}
-	makeV7(uuid[:])
+	if err := makeV7(uuid[:]); err != nil {
+		return Nil, err
+	}
 	return uuid, nil
 }
 
@@ -38,14 +41,16 @@ func NewV7FromReader(r io.Reader) (UUID, error) {
 		return uuid, err
 	}
 
-	makeV7(uuid[:])
+	if err := makeV7(uuid[:]); err != nil {
+		return Nil, err
+	}
 	return uuid, nil
 }
 
 // makeV7 fill 48 bits time (uuid[0] - uuid[5]), set version b0111 (uuid[6])
 // uuid[8] already has the right version number (Variant is 10)
 // see function NewV7 and NewV7FromReader
-func makeV7(uuid []byte) {
+func makeV7(uuid []byte) error {
 	/*
 		 0                   1                   2                   3
 		 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
@@ -61,8 +66,17 @@ func makeV7(uuid []byte) {
 	*/
 	_ = uuid[15] // bounds check
 
-	t, s := getV7Time()
+	t, s, err := getV7Time()
+	if err != nil {
+		return err
+	}
+	putV7Time(uuid, t, s)
+	return nil
+}
 
+// putV7Time fills the timestamp, version and sequence of a Version 7 UUID and
+// places the worker ID set by SetWorkerID, if any.
+func putV7Time(uuid []byte, t, s int64) {
 	uuid[0] = byte(t >> 40)
 	uuid[1] = byte(t >> 32)
 	uuid[2] = byte(t >> 24)
@@ -72,6 +86,8 @@ func makeV7(uuid []byte) {
 
 	uuid[6] = 0x70 | (0x0F & byte(s>>8))
 	uuid[7] = byte(s)
+
+	setWorker(uuid)
 }
 
 // lastV7time is the last time we returned stored as:
@@ -84,21 +100,41 @@ const nanoPerMilli = 1000000
 
 // getV7Time returns the time in milliseconds and nanoseconds / 256.
 // The returned (milli << 12 + seq) is guaranteed to be greater than
-// (milli << 12 + seq) returned by any previous call to getV7Time.
-func getV7Time() (milli, seq int64) {
+// (milli << 12 + seq) returned by any previous call to getV7Time, and by any
+// process using the same shared clock if EnableSharedClock was called.  Without
+// a shared clock this does not hold if ClockReseed is the policy set by
+// SetClockPolicy; the shared clock takes precedence over the policy.
+func getV7Time() (milli, seq int64, err error) {
 	timeMu.Lock()
 	defer timeMu.Unlock()
 
-	nano := timeNow().UnixNano()
-	milli = nano / nanoPerMilli
-	// Sequence number is between 0 and 3906 (nanoPerMilli>>8)
-	seq = (nano - milli*nanoPerMilli) >> 8
-	now := milli<<12 + seq
-	if now <= lastV7time {
-		now = lastV7time + 1
-		milli = now >> 12
-		seq = now & 0xfff
+	t, _ := readClock()
+	now := v7Time(t.UnixNano())
+	if next := lastV7time + 1; now < next {
+		// If next is in a later millisecond than the clock apply the
+		// policy set by SetClockPolicy, unless the shared clock is used.
+		if next>>12 > now>>12 && clockPolicy != ClockDefault && sharedClock == nil {
+			if next, err = v7Behind(now); err != nil {
+				return 0, 0, err
+			}
+		}
+		now = next
+	}
+	if sharedClock != nil {
+		if now, err = advanceShared(sharedV7Offset, now); err != nil {
+			return 0, 0, err
+		}
 	}
+	milli = now >> 12
+	seq = now & 0xfff
 	lastV7time = now
-	return milli, seq
+	return milli, seq, nil
+}
+
+// v7Time returns the Unix time nano as milli<<12 + seq.
+func v7Time(nano int64) int64 {
+	milli := nano / nanoPerMilli
+	// Sequence number is between 0 and 3906 (nanoPerMilli>>8)
+	seq := (nano - milli*nanoPerMilli) >> 8
+	return milli<<12 + seq
 }
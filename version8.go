@@ -0,0 +1,155 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// UUID version 8 leaves all but the version and variant bits to the
+// implementation.  Most version 8 UUIDs created by this package share the
+// layout of version 7, a 48 bit Unix millisecond timestamp first, so they sort
+// by time together with version 7 UUIDs.  The top 4 bits of custom_a hold a
+// tag identifying what the remaining 70 bits hold.  The UUIDs of a KeyedNamer
+// have no timestamp and hold a keyed hash in its place; see KeyedNamer.
+//
+// Nothing in a version 8 UUID tells which implementation created it.  A
+// version 8 UUID from elsewhere whose tag bits happen to match one of these
+// layouts is read as if this package had created it, so Time and the other
+// methods reading version 8 UUIDs only return meaningful values for UUIDs
+// created by this package.
+//
+// see https://datatracker.ietf.org/doc/html/rfc9562#name-uuid-version-8
+//
+//	 0                   1                   2                   3
+//	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|                           unix_ts_ms                          |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|          unix_ts_ms           |  ver  |  tag  |    custom_a   |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|var|                        custom_b                           |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|                            custom_b                           |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+// Tags of the version 8 UUIDs created by this package.
+const (
+	v8Snowflake = 0x1
+	v8ObjectID  = 0x2
+	v8TimeHash  = 0x3
+	v8Keyed     = 0x4
+	v8Signed    = 0x5
+)
+
+// SnowflakeEpoch is the epoch of Twitter Snowflake IDs, 4 Nov 2010 01:42:54.657
+// UTC.
+var SnowflakeEpoch = time.UnixMilli(1288834974657)
+
+// ErrSnowflakeRange is returned by FromSnowflake if the ID is negative or its
+// time cannot be represented in a UUID.
+var ErrSnowflakeRange = errors.New("snowflake ID out of range")
+
+// makeV8 sets the timestamp, version, tag and variant of a version 8 UUID.
+// The other bits of uuid are left untouched.
+func makeV8(uuid *UUID, milli int64, tag byte) {
+	putMilli(uuid, milli)
+	uuid[6] = 0x80 | tag              // Version 8
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // Variant is 10
+}
+
+// putMilli sets the 48 bit Unix millisecond timestamp of a version 7 or 8
+// UUID.
+func putMilli(uuid *UUID, milli int64) {
+	uuid[0] = byte(milli >> 40)
+	uuid[1] = byte(milli >> 32)
+	uuid[2] = byte(milli >> 24)
+	uuid[3] = byte(milli >> 16)
+	uuid[4] = byte(milli >> 8)
+	uuid[5] = byte(milli)
+}
+
+// v8Tag returns the tag of a version 8 UUID, assuming it was created by this
+// package, or 0 for other versions.
+func (uuid UUID) v8Tag() byte {
+	if uuid.Version() != 8 || uuid.Variant() != RFC4122 {
+		return 0
+	}
+	return uuid[6] & 0x0f
+}
+
+// v8HasTime reports whether uuid is a version 8 UUID starting with a Unix
+// millisecond timestamp.
+func (uuid UUID) v8HasTime() bool {
+	switch uuid.v8Tag() {
+	case v8Snowflake, v8ObjectID, v8TimeHash, v8Signed:
+		return true
+	}
+	return false
+}
+
+// FromSnowflake returns a version 8 UUID holding the Snowflake ID id, whose
+// timestamp counts milliseconds since epoch.  Use SnowflakeEpoch for Twitter
+// Snowflake IDs.  The UUID starts with the Unix millisecond time of id, so it
+// sorts among version 7 UUIDs by time, after those from the same millisecond.
+// The ID is stored in full and can be recovered by Snowflake.
+func FromSnowflake(id int64, epoch time.Time) (UUID, error) {
+	var uuid UUID
+	milli := id>>22 + epoch.UnixMilli()
+	if id < 0 || milli < 0 || milli >= 1<<48 {
+		return uuid, ErrSnowflakeRange
+	}
+	// The 63 bits of id fill custom_a and the top of custom_b.
+	uuid[7] = byte(id >> 55)
+	binary.BigEndian.PutUint64(uuid[8:], uint64(id)<<9>>2)
+	makeV8(&uuid, milli, v8Snowflake)
+	return uuid, nil
+}
+
+// Snowflake returns the Snowflake ID held in a UUID created by FromSnowflake.
+// ok is false if uuid was not created by FromSnowflake.
+func (uuid UUID) Snowflake() (id int64, ok bool) {
+	lo := binary.BigEndian.Uint64(uuid[8:])
+	if uuid.v8Tag() != v8Snowflake || lo&0x7f != 0 {
+		return 0, false
+	}
+	id = int64(uint64(uuid[7])<<55 | lo<<2>>9)
+	return id, true
+}
+
+// FromObjectID returns a version 8 UUID holding the 12 byte ID id, a MongoDB
+// ObjectID or an xid, both of which start with a 32 bit big-endian Unix time in
+// seconds.  The UUID starts with that time in milliseconds, so it sorts among
+// version 7 UUIDs by time, after those from the start of the same second.  The
+// ID is stored in full and can be recovered by ObjectID.
+func FromObjectID(id [12]byte) UUID {
+	var uuid UUID
+	milli := int64(binary.BigEndian.Uint32(id[0:4])) * 1000
+	// The 8 bytes after the time fill custom_a and the top of custom_b.
+	uuid[7] = id[4]
+	var b [8]byte
+	copy(b[:], id[5:])
+	binary.BigEndian.PutUint64(uuid[8:], binary.BigEndian.Uint64(b[:])>>2)
+	makeV8(&uuid, milli, v8ObjectID)
+	return uuid
+}
+
+// ObjectID returns the 12 byte ID held in a UUID created by FromObjectID.  ok
+// is false if uuid was not created by FromObjectID.
+func (uuid UUID) ObjectID() (id [12]byte, ok bool) {
+	lo := binary.BigEndian.Uint64(uuid[8:])
+	milli := int64(binary.BigEndian.Uint64(uuid[:8]) >> 16)
+	if uuid.v8Tag() != v8ObjectID || lo&0x3f != 0 || milli%1000 != 0 {
+		return id, false
+	}
+	binary.BigEndian.PutUint32(id[0:], uint32(milli/1000))
+	id[4] = uuid[7]
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], lo<<2)
+	copy(id[5:], b[:7])
+	return id, true
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestSnowflake(t *testing.T) {
+	const id = 1541815603606036480 // 2022-06-28T16:07:40.105Z
+	uuid, err := FromSnowflake(id, SnowflakeEpoch)
+	if err != nil {
+		t.Fatalf("FromSnowflake: %v", err)
+	}
+	if v := uuid.Version(); v != 8 {
+		t.Errorf("%s: version %s expected 8", uuid, v)
+	}
+	if v := uuid.Variant(); v != RFC4122 {
+		t.Errorf("%s: variant %s expected RFC4122", uuid, v)
+	}
+	if got, ok := uuid.Snowflake(); !ok || got != id {
+		t.Errorf("Snowflake got %d %v, want %d true", got, ok, id)
+	}
+	if _, ok := uuid.ObjectID(); ok {
+		t.Errorf("%s: ObjectID succeeded on a Snowflake UUID", uuid)
+	}
+	want := time.Date(2022, 6, 28, 16, 7, 40, 105000000, time.UTC)
+	if got := time.Unix(uuid.Time().UnixTime()); !got.Equal(want) {
+		t.Errorf("Time got %v, want %v", got.UTC(), want)
+	}
+
+	for _, id := range []int64{0, 1, 1<<63 - 1} {
+		uuid, err := FromSnowflake(id, time.UnixMilli(0))
+		if err != nil {
+			t.Fatalf("FromSnowflake(%d): %v", id, err)
+		}
+		if got, ok := uuid.Snowflake(); !ok || got != id {
+			t.Errorf("Snowflake got %d %v, want %d true", got, ok, id)
+		}
+	}
+	if _, err := FromSnowflake(-1, SnowflakeEpoch); err != ErrSnowflakeRange {
+		t.Errorf("FromSnowflake(-1) got error %v, want %v", err, ErrSnowflakeRange)
+	}
+	if _, ok := Must(NewV7()).Snowflake(); ok {
+		t.Error("Snowflake succeeded on a version 7 UUID")
+	}
+}
+
+func TestObjectID(t *testing.T) {
+	oid := [12]byte{0x50, 0x7f, 0x1f, 0x77, 0xbc, 0xf8, 0x6c, 0xd7, 0x99, 0x43, 0x90, 0x11}
+	uuid := FromObjectID(oid)
+	if v := uuid.Version(); v != 8 {
+		t.Errorf("%s: version %s expected 8", uuid, v)
+	}
+	if got, ok := uuid.ObjectID(); !ok || got != oid {
+		t.Errorf("ObjectID got %x %v, want %x true", got, ok, oid)
+	}
+	if _, ok := uuid.Snowflake(); ok {
+		t.Errorf("%s: Snowflake succeeded on an ObjectID UUID", uuid)
+	}
+	want := time.Unix(0x507f1f77, 0)
+	if got := time.Unix(uuid.Time().UnixTime()); !got.Equal(want) {
+		t.Errorf("Time got %v, want %v", got, want)
+	}
+}
+
+// v7At returns the smallest and largest version 7 UUIDs of Unix millisecond
+// milli.
+func v7At(milli int64) (first, last UUID) {
+	hi := uint64(milli)<<16 | 0x7000
+	return FromUint64Pair(hi, 1<<63), FromUint64Pair(hi|0xfff, 1<<63|(1<<62-1))
+}
+
+func TestVersion8Order(t *testing.T) {
+	milli := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).UnixMilli()
+	var oid [12]byte
+	binary.BigEndian.PutUint32(oid[:], uint32(milli/1000))
+	sf, err := FromSnowflake((milli-SnowflakeEpoch.UnixMilli())<<22|0x3fffff, SnowflakeEpoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, before := v7At(milli)
+	after, _ := v7At(milli + 1)
+	for _, uuid := range []UUID{FromObjectID(oid), sf} {
+		if Compare(before, uuid) >= 0 || Compare(uuid, after) >= 0 {
+			t.Errorf("%s does not sort between %s and %s", uuid, before, after)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+)
+
+// A workerConfig is the worker ID placed in version 7 UUIDs.
+type workerConfig struct {
+	id      uint64
+	bits    uint
+	counter bool
+	seq     uint64 // accessed atomically
+}
+
+var (
+	workerMu      sync.Mutex // protects the settings below and changes to v7Worker
+	workerIDValue uint64
+	workerBits    uint
+	workerCounter bool
+
+	v7Worker atomic.Value // *workerConfig built from the settings, nil if none
+)
+
+// SetWorkerID sets the worker ID placed in the leading bits of rand_b of
+// Version 7 UUIDs, as in Snowflake IDs.  If every process generating UUIDs
+// has a different worker ID their UUIDs never collide: the timestamp and
+// sequence in rand_a are unique within a process and the worker ID tells the
+// processes apart.  bits is the number of bits the worker ID takes, at most
+// 62, and id must fit in them.  The remaining bits of rand_b stay random,
+// unless SetWorkerCounter is used.  Fewer random bits make ShardKey, Bucket
+// and Sample depend on the worker ID of a UUID.
+//
+// If bits is 0 the worker ID is removed and rand_b is entirely random again.
+// If bits is out of range or id does not fit then false is returned and the
+// worker ID is not changed.
+func SetWorkerID(id uint64, bits int) bool {
+	if bits < 0 || bits > 62 || id>>uint(bits) != 0 {
+		return false
+	}
+	defer workerMu.Unlock()
+	workerMu.Lock()
+	workerIDValue, workerBits = id, uint(bits)
+	storeWorker()
+	return true
+}
+
+// WorkerID returns the worker ID set by SetWorkerID and the number of bits it
+// takes.  Both are 0 if no worker ID is set.
+func WorkerID() (id uint64, bits int) {
+	defer workerMu.Unlock()
+	workerMu.Lock()
+	return workerIDValue, int(workerBits)
+}
+
+// SetWorkerCounter selects what follows the worker ID in rand_b.  If counter
+// is true it is a counter incremented for every Version 7 UUID, which makes
+// the UUIDs of a worker entirely deterministic.  If counter is false, the
+// default, it is random.  The counter is only used while a worker ID is set
+// with SetWorkerID.
+func SetWorkerCounter(counter bool) {
+	defer workerMu.Unlock()
+	workerMu.Lock()
+	workerCounter = counter
+	storeWorker()
+}
+
+// storeWorker publishes the current settings to setWorker.  workerMu must be
+// held.
+func storeWorker() {
+	if workerBits == 0 {
+		v7Worker.Store((*workerConfig)(nil))
+		return
+	}
+	v7Worker.Store(&workerConfig{id: workerIDValue, bits: workerBits, counter: workerCounter})
+}
+
+// WorkerID returns the worker ID held in the leading bits of rand_b of a
+// Version 7 UUID generated while SetWorkerID was in effect.  bits must be the
+// number of bits passed to SetWorkerID.
+func (uuid UUID) WorkerID(bits int) uint64 {
+	if bits <= 0 || bits > 62 {
+		return 0
+	}
+	randB := binary.BigEndian.Uint64(uuid[8:]) & (1<<62 - 1)
+	return randB >> (62 - uint(bits))
+}
+
+// setWorker replaces the leading bits of rand_b in uuid with the worker ID, if
+// one is set, and the rest with the counter if SetWorkerCounter is on.
+func setWorker(uuid []byte) {
+	w, _ := v7Worker.Load().(*workerConfig)
+	if w == nil {
+		return
+	}
+	restBits := 62 - w.bits
+	rest := binary.BigEndian.Uint64(uuid[8:])
+	if w.counter {
+		rest = atomic.AddUint64(&w.seq, 1)
+	}
+	rest &= 1<<restBits - 1
+	binary.BigEndian.PutUint64(uuid[8:], 0x8000000000000000|w.id<<restBits|rest) // Variant is 10
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Google Inc.  All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestSetWorkerID(t *testing.T) {
+	defer SetWorkerID(0, 0)
+
+	for _, tt := range []struct {
+		id   uint64
+		bits int
+		ok   bool
+	}{
+		{0, 0, true},
+		{1, 1, true},
+		{2, 1, false},
+		{1023, 10, true},
+		{1<<62 - 1, 62, true},
+		{0, 63, false},
+		{0, -1, false},
+	} {
+		if ok := SetWorkerID(tt.id, tt.bits); ok != tt.ok {
+			t.Errorf("SetWorkerID(%d, %d) got %v, want %v", tt.id, tt.bits, ok, tt.ok)
+		}
+	}
+	if id, bits := WorkerID(); id != 1<<62-1 || bits != 62 {
+		t.Errorf("WorkerID got %d, %d", id, bits)
+	}
+
+	if !SetWorkerID(0x2a5, 10) {
+		t.Fatal("SetWorkerID failed")
+	}
+	prev := Must(NewV7())
+	for i := 0; i < 1000; i++ {
+		uuid := Must(NewV7())
+		if v := uuid.Version(); v != 7 {
+			t.Fatalf("%s: version %s expected 7", uuid, v)
+		}
+		if v := uuid.Variant(); v != RFC4122 {
+			t.Fatalf("%s: variant %s expected RFC4122", uuid, v)
+		}
+		if id := uuid.WorkerID(10); id != 0x2a5 {
+			t.Fatalf("%s: WorkerID got %#x, want 0x2a5", uuid, id)
+		}
+		if Compare(prev, uuid) >= 0 {
+			t.Fatalf("monotonicity failed: %s(next) < %s(before)", uuid, prev)
+		}
+		prev = uuid
+	}
+
+	SetWorkerID(0, 0)
+	if id, bits := WorkerID(); id != 0 || bits != 0 {
+		t.Errorf("WorkerID after reset got %d, %d", id, bits)
+	}
+}
+
+func TestSetWorkerCounter(t *testing.T) {
+	defer SetWorkerID(0, 0)
+	defer SetWorkerCounter(false)
+
+	SetWorkerCounter(true)
+	SetWorkerID(7, 4)
+	rest := func(uuid UUID) uint64 {
+		return binary.BigEndian.Uint64(uuid[8:]) & (1<<58 - 1)
+	}
+	u1 := Must(NewV7())
+	u2 := Must(NewV7())
+	if rest(u2) != rest(u1)+1 {
+		t.Errorf("counter went from %d to %d", rest(u1), rest(u2))
+	}
+	if u1.WorkerID(4) != 7 || u2.WorkerID(4) != 7 {
+		t.Errorf("got worker IDs %d and %d, want 7", u1.WorkerID(4), u2.WorkerID(4))
+	}
+}